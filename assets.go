@@ -0,0 +1,127 @@
+package main
+
+import (
+	"embed"
+	"errors"
+	"image"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// built-in bitmaps, assets/logo/raspberry.png is registered as "logo/raspberry"
+//
+//go:embed assets
+var embeddedAssets embed.FS
+
+// file extensions tried when looking an asset up, in order
+var assetExts = []string{".png", ".gif", ".jpg", ".jpeg", ".bmp"}
+
+var (
+	assetMu  sync.RWMutex
+	assetReg = map[string]image.Image{}
+	assetDir string
+)
+
+var ErrAssetNotFound = errors.New("asset not found")
+
+// RegisterAsset adds or replaces the bitmap stored under name, e.g. "icon/wifi-3"
+func RegisterAsset(name string, img image.Image) {
+	assetMu.Lock()
+	assetReg[name] = img
+	assetMu.Unlock()
+}
+
+// SetAssetDir lets users override assets at runtime: "icon/wifi-3" is then
+// first looked up as dir/icon/wifi-3.png (or .gif, .jpg, .jpeg, .bmp).
+// An empty dir disables the overrides.
+func SetAssetDir(dir string) {
+	assetMu.Lock()
+	assetDir = dir
+	assetMu.Unlock()
+}
+
+// LookupAsset returns the bitmap for name. A file in the asset directory wins
+// over a registered bitmap, which wins over the embedded one. Files are only
+// looked up for slash separated names inside the directory (see
+// fs.ValidPath), so "../x" or "/etc/x" are never read.
+func LookupAsset(name string) (image.Image, error) {
+	assetMu.RLock()
+	dir := assetDir
+	img, ok := assetReg[name]
+	assetMu.RUnlock()
+
+	valid := fs.ValidPath(name) && !strings.Contains(name, `\`)
+	if dir != "" && valid {
+		for _, ext := range assetExts {
+			file := filepath.Join(dir, filepath.FromSlash(name)+ext)
+			if _, err := os.Stat(file); err == nil {
+				return LoadImage(file)
+			}
+		}
+	}
+	if ok {
+		return img, nil
+	}
+	if !valid {
+		return nil, ErrAssetNotFound
+	}
+
+	for _, ext := range assetExts {
+		f, err := embeddedAssets.Open(path.Join("assets", name+ext))
+		if err != nil {
+			continue
+		}
+		img, _, err := image.Decode(f)
+		f.Close()
+		if err != nil {
+			return nil, err
+		}
+		// decode the embedded file only once
+		RegisterAsset(name, img)
+		return img, nil
+	}
+	return nil, ErrAssetNotFound
+}
+
+// AssetNames lists the registered and embedded assets
+func AssetNames() []string {
+	seen := map[string]bool{}
+
+	assetMu.RLock()
+	for name := range assetReg {
+		seen[name] = true
+	}
+	assetMu.RUnlock()
+
+	fs.WalkDir(embeddedAssets, "assets", func(p string, d fs.DirEntry, err error) error {
+		if err == nil && !d.IsDir() {
+			name := strings.TrimPrefix(p, "assets/")
+			seen[strings.TrimSuffix(name, path.Ext(name))] = true
+		}
+		return nil
+	})
+
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ShowSplash draws the named asset centered on a cleared screen and displays it
+func (pin PCD8544_pin) ShowSplash(name string) error {
+	img, err := LookupAsset(name)
+	if err != nil {
+		return err
+	}
+	LCDClear()
+	LCDDrawImage(img, 0, 0, int(LCDWIDTH), int(LCDHEIGHT), ImageCenter)
+	pin.LCDDisplay()
+	return nil
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLookupAsset(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, "assets")
+	for _, file := range []string{"outside.png", "assets/icon/inside.png"} {
+		path := filepath.Join(root, filepath.FromSlash(file))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		f, err := os.Create(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		png.Encode(f, image.NewGray(image.Rect(0, 0, 3, 2)))
+		f.Close()
+	}
+	SetAssetDir(dir)
+	defer SetAssetDir("")
+
+	if img, err := LookupAsset("icon/inside"); err != nil || img.Bounds().Dx() != 3 {
+		t.Errorf("icon/inside: %v", err)
+	}
+	if _, err := LookupAsset("logo/raspberry"); err != nil {
+		t.Errorf("embedded logo/raspberry: %v", err)
+	}
+	for _, name := range []string{
+		"../outside",
+		"icon/../../outside",
+		filepath.ToSlash(filepath.Join(root, "outside"))[1:],
+		"/" + filepath.ToSlash(filepath.Join(root, "outside"))[1:],
+		`icon\..\..\outside`,
+		"",
+	} {
+		if _, err := LookupAsset(name); err != ErrAssetNotFound {
+			t.Errorf("%q: error %v, want ErrAssetNotFound", name, err)
+		}
+	}
+}
@@ -0,0 +1,227 @@
+package main
+
+import (
+	"bufio"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image"
+	"io"
+	"io/fs"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// LoadBDF loads an X11 BDF bitmap font from a file
+func LoadBDF(path string) (*BitmapFont, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ParseBDF(f)
+}
+
+// LoadBDFFS loads a BDF font from fsys, e.g. an embed.FS
+func LoadBDFFS(fsys fs.FS, name string) (*BitmapFont, error) {
+	f, err := fsys.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ParseBDF(f)
+}
+
+// ParseBDF reads a BDF font. The ENCODING of a glyph is taken as its rune,
+// which is right for ISO10646 and ISO8859-1 fonts; unencoded glyphs are
+// skipped. The line height is FONT_ASCENT + FONT_DESCENT, or the font
+// bounding box if those properties are missing. Input that does not start
+// with STARTFONT or has no glyphs is an error.
+func ParseBDF(r io.Reader) (*BitmapFont, error) {
+	p := bdfParser{sc: bufio.NewScanner(r)}
+	f := &BitmapFont{Glyphs: map[rune]Glyph{}}
+
+	if key, _, ok := p.next(); !ok || key != "STARTFONT" {
+		if err := p.sc.Err(); err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, errors.New("bdf: empty font")
+		}
+		return nil, p.errorf("not a BDF font, missing STARTFONT")
+	}
+
+	var bbox [4]int
+	ascent, descent := -1, -1
+	advance := -1
+	for {
+		key, args, ok := p.next()
+		if !ok {
+			break
+		}
+		switch key {
+		case "FONTBOUNDINGBOX":
+			if err := p.ints(args, bbox[:]); err != nil {
+				return nil, err
+			}
+		case "FONT_ASCENT", "FONT_DESCENT":
+			var v [1]int
+			if err := p.ints(args, v[:]); err != nil {
+				return nil, err
+			}
+			if key == "FONT_ASCENT" {
+				ascent = v[0]
+			} else {
+				descent = v[0]
+			}
+		case "DWIDTH":
+			// a default for glyphs without their own DWIDTH
+			var v [2]int
+			if err := p.ints(args, v[:]); err != nil {
+				return nil, err
+			}
+			advance = v[0]
+		case "STARTCHAR":
+			r, g, err := p.glyph(advance, bbox)
+			if err != nil {
+				return nil, err
+			}
+			if r >= 0 {
+				f.Glyphs[r] = g
+			}
+		case "ENDFONT":
+			return f.finish(ascent, descent, bbox)
+		}
+	}
+	if err := p.sc.Err(); err != nil {
+		return nil, err
+	}
+	return f.finish(ascent, descent, bbox)
+}
+
+// finish sets the metrics of a parsed BDF font, which must have glyphs
+func (f *BitmapFont) finish(ascent, descent int, bbox [4]int) (*BitmapFont, error) {
+	if len(f.Glyphs) == 0 {
+		return nil, errors.New("bdf: font has no glyphs")
+	}
+	if ascent < 0 {
+		ascent = bbox[1] + bbox[3]
+	}
+	if descent < 0 {
+		descent = -bbox[3]
+	}
+	f.FontMetrics = FontMetrics{Height: ascent + descent, Ascent: ascent}
+	return f, nil
+}
+
+type bdfParser struct {
+	sc   *bufio.Scanner
+	line int
+}
+
+// next returns the keyword and arguments of the next non-empty line
+func (p *bdfParser) next() (key string, args []string, ok bool) {
+	for p.sc.Scan() {
+		p.line++
+		fields := strings.Fields(p.sc.Text())
+		if len(fields) > 0 {
+			return fields[0], fields[1:], true
+		}
+	}
+	return "", nil, false
+}
+
+func (p *bdfParser) errorf(format string, a ...interface{}) error {
+	return fmt.Errorf("bdf: line %d: %s", p.line, fmt.Sprintf(format, a...))
+}
+
+// ints parses the first len(v) arguments
+func (p *bdfParser) ints(args []string, v []int) error {
+	if len(args) < len(v) {
+		return p.errorf("need %d numbers", len(v))
+	}
+	for i := range v {
+		n, err := strconv.Atoi(args[i])
+		if err != nil {
+			return p.errorf("bad number %q", args[i])
+		}
+		v[i] = n
+	}
+	return nil
+}
+
+// glyph parses a glyph up to ENDCHAR, r is -1 for an unencoded glyph
+func (p *bdfParser) glyph(advance int, bbox [4]int) (r rune, g Glyph, err error) {
+	r = -1
+	box := bbox
+	for {
+		key, args, ok := p.next()
+		if !ok {
+			return 0, g, p.errorf("missing ENDCHAR")
+		}
+		switch key {
+		case "ENCODING":
+			var v [1]int
+			if err := p.ints(args, v[:]); err != nil {
+				return 0, g, err
+			}
+			if v[0] >= 0 {
+				r = rune(v[0])
+			}
+		case "DWIDTH":
+			var v [2]int
+			if err := p.ints(args, v[:]); err != nil {
+				return 0, g, err
+			}
+			advance = v[0]
+		case "BBX":
+			if err := p.ints(args, box[:]); err != nil {
+				return 0, g, err
+			}
+		case "BITMAP":
+			bm, err := p.bitmap(box[0], box[1])
+			if err != nil {
+				return 0, g, err
+			}
+			g.Bitmap = bm
+		case "ENDCHAR":
+			if advance < 0 {
+				advance = box[0]
+			}
+			g.Advance = advance
+			// BBX has the bottom left corner above the baseline, Offset
+			// the top left corner below it
+			g.Offset = image.Pt(box[2], -(box[3] + box[1]))
+			return r, g, nil
+		}
+	}
+}
+
+// bitmap reads the h hex rows of a w pixel wide glyph
+func (p *bdfParser) bitmap(w, h int) (*Bitmap, error) {
+	if w < 0 || h < 0 {
+		return nil, p.errorf("bad glyph size %dx%d", w, h)
+	}
+	stride := (w + 7) / 8
+	bm := &Bitmap{Width: w, Height: h, Format: RowFormat, Data: make([]byte, stride*h)}
+	for y := 0; y < h; y++ {
+		if !p.sc.Scan() {
+			return nil, p.errorf("short bitmap")
+		}
+		p.line++
+		row := strings.TrimSpace(p.sc.Text())
+		if len(row) > 2*stride {
+			row = row[:2*stride]
+		}
+		if len(row)%2 != 0 {
+			row += "0"
+		}
+		b, err := hex.DecodeString(row)
+		if err != nil {
+			return nil, p.errorf("bad bitmap row %q", row)
+		}
+		copy(bm.Data[y*stride:], b)
+	}
+	return bm, nil
+}
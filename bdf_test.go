@@ -0,0 +1,86 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+const testBDF = `STARTFONT 2.1
+FONT -test-fixed-medium-r-normal--8-80-75-75-c-40-iso10646-1
+SIZE 8 75 75
+FONTBOUNDINGBOX 4 8 0 -2
+STARTPROPERTIES 2
+FONT_ASCENT 6
+FONT_DESCENT 2
+ENDPROPERTIES
+CHARS 2
+STARTCHAR A
+ENCODING 65
+SWIDTH 500 0
+DWIDTH 5 0
+BBX 3 5 0 0
+BITMAP
+40
+A0
+E0
+A0
+A0
+ENDCHAR
+STARTCHAR unencoded
+ENCODING -1
+DWIDTH 4 0
+BBX 1 1 0 0
+BITMAP
+80
+ENDCHAR
+ENDFONT
+`
+
+func TestParseBDF(t *testing.T) {
+	f, err := ParseBDF(strings.NewReader(testBDF))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m := f.Metrics(); m.Height != 8 || m.Ascent != 6 {
+		t.Errorf("metrics %+v, want height 8, ascent 6", m)
+	}
+	if len(f.Glyphs) != 1 {
+		t.Errorf("%d glyphs, want only the encoded one", len(f.Glyphs))
+	}
+	g, ok := f.Glyph('A')
+	if !ok {
+		t.Fatal("no glyph for A")
+	}
+	if g.Advance != 5 || g.Offset.X != 0 || g.Offset.Y != -5 {
+		t.Errorf("advance %d offset %v, want 5 and (0,-5)", g.Advance, g.Offset)
+	}
+	want := []string{".#.", "#.#", "###", "#.#", "#.#"}
+	for y, row := range want {
+		for x, c := range row {
+			if g.Bitmap.At(x, y) != (c == '#') {
+				t.Errorf("pixel %d,%d is %v", x, y, g.Bitmap.At(x, y))
+			}
+		}
+	}
+
+	var buf [6][LCDWIDTH]byte
+	if x := NewCanvas(&buf).DrawText(f, 0, 0, "AA"); x != 10 || len(pixels(&buf)) != 2*10 {
+		t.Errorf("DrawText: next x %d, %d pixels", x, len(pixels(&buf)))
+	}
+}
+
+func TestParseBDFErrors(t *testing.T) {
+	for name, src := range map[string]string{
+		"empty":       "",
+		"garbage":     "this is not a font\nat all\n",
+		"png":         "\x89PNG\r\n\x1a\n",
+		"no glyphs":   "STARTFONT 2.1\nFONTBOUNDINGBOX 4 8 0 -2\nCHARS 0\nENDFONT\n",
+		"unencoded":   "STARTFONT 2.1\nSTARTCHAR x\nENCODING -1\nBBX 1 1 0 0\nBITMAP\n80\nENDCHAR\nENDFONT\n",
+		"no ENDCHAR":  "STARTFONT 2.1\nSTARTCHAR A\nENCODING 65\n",
+		"short glyph": "STARTFONT 2.1\nSTARTCHAR A\nENCODING 65\nBBX 3 5 0 0\nBITMAP\n40\n",
+	} {
+		if f, err := ParseBDF(strings.NewReader(src)); err == nil {
+			t.Errorf("%s: no error, got %d glyphs", name, len(f.Glyphs))
+		}
+	}
+}
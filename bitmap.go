@@ -0,0 +1,152 @@
+package main
+
+import (
+	"image"
+)
+
+// BitmapFormat is the memory layout of Bitmap.Data
+type BitmapFormat int
+
+const (
+	// the layout of pcd8544_buffer: ceil(h/8) pages of w bytes, bit n of a
+	// byte is the pixel in row page*8+n
+	PageFormat BitmapFormat = iota
+	// row-major: h rows of ceil(w/8) bytes, the most significant bit of a
+	// byte is the leftmost pixel
+	RowFormat
+)
+
+// Bitmap is a 1-bit image, set bits are black
+type Bitmap struct {
+	Width, Height int
+	Format        BitmapFormat
+	Data          []byte
+}
+
+// NewBitmap returns a cleared page format bitmap, negative sizes are taken as 0
+func NewBitmap(w, h int) *Bitmap {
+	if w < 0 {
+		w = 0
+	}
+	if h < 0 {
+		h = 0
+	}
+	return &Bitmap{
+		Width:  w,
+		Height: h,
+		Format: PageFormat,
+		Data:   make([]byte, w*((h+7)/8)),
+	}
+}
+
+// BitmapFromImage converts img with ImageThreshold. The mask is set where img
+// is opaque, so it can be drawn transparently with Blit.
+func BitmapFromImage(img image.Image) (bm, mask *Bitmap) {
+	r := img.Bounds()
+	bm = NewBitmap(r.Dx(), r.Dy())
+	mask = NewBitmap(r.Dx(), r.Dy())
+	for y := 0; y < r.Dy(); y++ {
+		for x := 0; x < r.Dx(); x++ {
+			l, a := lumaAlpha(img.At(r.Min.X+x, r.Min.Y+y))
+			if a >= 0x8000 {
+				mask.Set(x, y, true)
+				bm.Set(x, y, l < uint32(ImageThreshold)<<8)
+			}
+		}
+	}
+	return bm, mask
+}
+
+// At reports whether the pixel at (x, y) is set
+func (b *Bitmap) At(x, y int) bool {
+	if x < 0 || y < 0 || x >= b.Width || y >= b.Height {
+		return false
+	}
+	i, bit := b.index(x, y)
+	return i < len(b.Data) && b.Data[i]&bit != 0
+}
+
+// Set sets or clears the pixel at (x, y)
+func (b *Bitmap) Set(x, y int, on bool) {
+	if x < 0 || y < 0 || x >= b.Width || y >= b.Height {
+		return
+	}
+	i, bit := b.index(x, y)
+	if i >= len(b.Data) {
+		return
+	}
+	if on {
+		b.Data[i] |= bit
+	} else {
+		b.Data[i] &^= bit
+	}
+}
+
+func (b *Bitmap) index(x, y int) (int, byte) {
+	if b.Format == RowFormat {
+		return y*((b.Width+7)/8) + x/8, 0x80 >> uint(x&7)
+	}
+	return (y>>3)*b.Width + x, 1 << uint(y&7)
+}
+
+// pageByte returns the 8 pixels of column x in page p, rows past the bottom are 0
+func (b *Bitmap) pageByte(x, p int) byte {
+	var v byte
+	if b.Format == PageFormat {
+		if i := p*b.Width + x; i < len(b.Data) {
+			v = b.Data[i]
+		}
+	} else {
+		for i := 0; i < 8; i++ {
+			if b.At(x, p*8+i) {
+				v |= 1 << uint(i)
+			}
+		}
+	}
+	return v & rowMask(p, 0, b.Height)
+}
+
+// DrawBitmap draws a page format bitmap of w*h pixels at (x, y), y does not
+// need to be a multiple of 8
+func (c *Canvas) DrawBitmap(x, y, w, h int, data []byte) {
+	c.Blit(x, y, &Bitmap{Width: w, Height: h, Format: PageFormat, Data: data}, nil)
+}
+
+// Blit draws src with its top left corner at (x, y). Set pixels are drawn
+// with the draw mode, clear ones are background (see SetTransparent). If
+// mask is not nil only the pixels set in mask are touched.
+func (c *Canvas) Blit(x, y int, src, mask *Bitmap) {
+	if src == nil {
+		return
+	}
+	clip := c.Clip()
+	x0, x1 := x, x+src.Width
+	if x0 < clip.Min.X {
+		x0 = clip.Min.X
+	}
+	if x1 > clip.Max.X {
+		x1 = clip.Max.X
+	}
+
+	// every source page lands on two destination pages, shifted by y&7
+	shift := uint(y & 7)
+	pages := (src.Height + 7) / 8
+	for dx := x0; dx < x1; dx++ {
+		sx := dx - x
+		for sp := 0; sp < pages; sp++ {
+			bits := src.pageByte(sx, sp)
+			sel := rowMask(sp, 0, src.Height)
+			if mask != nil {
+				sel &= mask.pageByte(sx, sp)
+			}
+			if sel == 0 {
+				continue
+			}
+			p := y>>3 + sp
+			c.writeByte(dx, p, bits<<shift, sel<<shift)
+			if shift != 0 {
+				c.writeByte(dx, p+1, bits>>(8-shift), sel>>(8-shift))
+			}
+		}
+	}
+}
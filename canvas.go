@@ -0,0 +1,323 @@
+package main
+
+import (
+	"image"
+)
+
+// the whole 84x48 screen
+var screenRect = image.Rect(0, 0, int(LCDWIDTH), int(LCDHEIGHT))
+
+// DrawMode is the raster operation used for the pixels a primitive draws
+type DrawMode uint8
+
+const (
+	DrawSet    DrawMode = iota // turn pixels on (black)
+	DrawClear                  // turn pixels off (white)
+	DrawInvert                 // flip pixels (XOR)
+)
+
+// Canvas draws into a page buffer using int coordinates. Anything outside
+// the clip rectangle is ignored, so shapes may be partly or completely off
+// the screen. A canvas can also be a view onto part of the buffer, see
+// SubCanvas.
+type Canvas struct {
+	buf *[6][LCDWIDTH]byte
+	// where (0, 0) of the canvas is in the buffer
+	origin image.Point
+	// the area of the view and the part of it the clip rectangle may
+	// cover, both in buffer coordinates like clip
+	bounds, limit image.Rectangle
+	clip          image.Rectangle
+
+	mode DrawMode
+	// bitmaps leave their background pixels untouched instead of drawing
+	// them with the opposite of mode
+	transparent bool
+	// width, dashes and caps of lines, rectangle and path outlines
+	style LineStyle
+	// shading of the filled shapes
+	pattern Pattern
+	// horizontal and vertical size factor of text
+	textScale image.Point
+	textColor TextColor
+	// if not nil, every pixel drawn is also set here, see Layer
+	cover *[6][LCDWIDTH]byte
+}
+
+// Screen is the canvas of pcd8544_buffer, the LCD* drawing functions use it
+var Screen = NewCanvas(&pcd8544_buffer)
+
+// NewCanvas returns a canvas drawing into buf, clipped to the whole screen
+func NewCanvas(buf *[6][LCDWIDTH]byte) *Canvas {
+	return &Canvas{
+		buf:       buf,
+		bounds:    screenRect,
+		limit:     screenRect,
+		clip:      screenRect,
+		pattern:   PatternSolid,
+		textScale: image.Point{1, 1},
+		textColor: TextNormal,
+	}
+}
+
+// SubCanvas returns a view onto the part r of the canvas. The view has its
+// own origin at r.Min and its own clip rectangle, which never reaches past
+// r or the clip rectangle c has now. It starts with the draw mode, line
+// style and fill pattern of c. Views share the buffer and can be nested.
+func (c *Canvas) SubCanvas(r image.Rectangle) *Canvas {
+	r = r.Add(c.origin)
+	sub := *c
+	sub.origin = r.Min
+	sub.bounds = r
+	sub.limit = r.Intersect(c.clip)
+	sub.clip = sub.limit
+	return &sub
+}
+
+// maskCanvas returns a canvas drawing into mask with the same origin and
+// clip as c, for rendering a shape before it is drawn with blend or fillMask
+func (c *Canvas) maskCanvas(mask *[6][LCDWIDTH]byte) *Canvas {
+	m := NewCanvas(mask)
+	m.origin, m.bounds, m.limit, m.clip = c.origin, c.bounds, c.limit, c.clip
+	return m
+}
+
+// Bounds returns the area of the canvas, (0, 0) is its top left corner
+func (c *Canvas) Bounds() image.Rectangle {
+	return c.bounds.Sub(c.origin)
+}
+
+// Clip returns the current clip rectangle
+func (c *Canvas) Clip() image.Rectangle {
+	return c.clip.Sub(c.origin)
+}
+
+// SetClip limits all drawing to r (intersected with the canvas)
+func (c *Canvas) SetClip(r image.Rectangle) {
+	c.clip = r.Add(c.origin).Intersect(c.limit)
+}
+
+// ResetClip removes the clip rectangle
+func (c *Canvas) ResetClip() {
+	c.clip = c.limit
+}
+
+// Mode returns the current draw mode
+func (c *Canvas) Mode() DrawMode {
+	return c.mode
+}
+
+// SetMode selects how the following primitives change the pixels they draw
+func (c *Canvas) SetMode(mode DrawMode) {
+	c.mode = mode
+}
+
+// Transparent reports whether bitmaps are drawn without background
+func (c *Canvas) Transparent() bool {
+	return c.transparent
+}
+
+// SetTransparent switches bitmaps between copy (false), where the background
+// pixels are drawn too, and transparent (true) drawing. Text has its own
+// colours, see SetTextColor.
+func (c *Canvas) SetTransparent(transparent bool) {
+	c.transparent = transparent
+}
+
+// Clear clears every pixel inside the clip rectangle
+func (c *Canvas) Clear() {
+	for y := c.clip.Min.Y; y < c.clip.Max.Y; y++ {
+		for x := c.clip.Min.X; x < c.clip.Max.X; x++ {
+			c.buf[y>>3][x] &^= 1 << uint(y&7)
+			if c.cover != nil {
+				c.cover[y>>3][x] |= 1 << uint(y&7)
+			}
+		}
+	}
+}
+
+// Pixel reports whether the pixel at (x, y) is set
+func (c *Canvas) Pixel(x, y int) bool {
+	x, y = x+c.origin.X, y+c.origin.Y
+	if !(image.Point{x, y}).In(c.bounds.Intersect(screenRect)) {
+		return false
+	}
+	return c.buf[y>>3][x]&(1<<uint(y&7)) != 0
+}
+
+// DrawPixel draws the pixel at (x, y)
+func (c *Canvas) DrawPixel(x, y int) {
+	c.plot(x, y)
+}
+
+// plot draws one foreground pixel
+func (c *Canvas) plot(x, y int) {
+	bit := byte(1) << uint(y&7)
+	c.writeByte(x, y>>3, bit, bit)
+}
+
+// writeByte draws the pixels of page p in column x that are selected by mask.
+// Pixels set in bits are foreground and drawn with the draw mode, the others
+// are background. Every write to the buffer ends up here, or in a routine
+// that clips the same way.
+func (c *Canvas) writeByte(x, p int, bits, mask byte) {
+	x += c.origin.X
+	p += c.origin.Y >> 3
+	// a view that does not start on a page boundary spreads the byte over
+	// two pages of the buffer
+	shift := uint(c.origin.Y & 7)
+	if shift == 0 {
+		c.put(x, p, bits, mask)
+		return
+	}
+	c.put(x, p, bits<<shift, mask<<shift)
+	c.put(x, p+1, bits>>(8-shift), mask>>(8-shift))
+}
+
+// put is writeByte in buffer coordinates
+func (c *Canvas) put(x, p int, bits, mask byte) {
+	if x < c.clip.Min.X || x >= c.clip.Max.X || p < 0 || p >= 6 {
+		return
+	}
+	mask &= c.pageMask(p)
+	fg := bits & mask
+	bg := mask &^ bits
+	if c.transparent {
+		bg = 0
+	}
+
+	b := &c.buf[p][x]
+	switch c.mode {
+	case DrawSet:
+		*b = (*b | fg) &^ bg
+	case DrawClear:
+		*b = (*b &^ fg) | bg
+	case DrawInvert:
+		*b ^= fg
+		bg = 0
+	}
+	if c.cover != nil {
+		c.cover[p][x] |= fg | bg
+	}
+}
+
+// pageMask returns the rows of buffer page p that are inside the clip rectangle
+func (c *Canvas) pageMask(p int) byte {
+	return rowMask(p, c.clip.Min.Y, c.clip.Max.Y)
+}
+
+// rowMask returns the bits of page p for the rows y0 <= y < y1
+func rowMask(p, y0, y1 int) byte {
+	lo, hi := y0-p*8, y1-p*8
+	if lo < 0 {
+		lo = 0
+	}
+	if hi > 8 {
+		hi = 8
+	}
+	if lo >= hi {
+		return 0
+	}
+	return byte(0xff<<uint(lo)) & byte(0xff>>uint(8-hi))
+}
+
+// fillColumn fills the pixels y0 <= y <= y1 of column x, a page byte at a time
+func (c *Canvas) fillColumn(x, y0, y1 int) {
+	clip := c.Clip()
+	if x < clip.Min.X || x >= clip.Max.X {
+		return
+	}
+	if y0 < clip.Min.Y {
+		y0 = clip.Min.Y
+	}
+	if y1 >= clip.Max.Y {
+		y1 = clip.Max.Y - 1
+	}
+	for p := y0 >> 3; y0 <= y1 && p <= y1>>3; p++ {
+		c.fillByte(x, p, rowMask(p, y0, y1+1))
+	}
+}
+
+// DrawLine draws a line from (x0, y0) to (x1, y1), both ends included,
+// using the line style of the canvas
+func (c *Canvas) DrawLine(x0, y0, x1, y1 int) {
+	if !c.style.plain() {
+		c.strokePolylines([][]image.Point{{{x0, y0}, {x1, y1}}})
+		return
+	}
+	c.drawLine(x0, y0, x1, y1)
+}
+
+// drawLine draws a solid 1 pixel line
+func (c *Canvas) drawLine(x0, y0, x1, y1 int) {
+	steep := abs(y1-y0) > abs(x1-x0)
+	if steep {
+		x0, y0 = y0, x0
+		x1, y1 = y1, x1
+	}
+	if x0 > x1 {
+		x0, x1 = x1, x0
+		y0, y1 = y1, y0
+	}
+
+	// visible range along the major axis
+	clip := c.Clip()
+	lo, hi := clip.Min.X, clip.Max.X-1
+	if steep {
+		lo, hi = clip.Min.Y, clip.Max.Y-1
+	}
+	if lo < x0 {
+		lo = x0
+	}
+	if hi > x1 {
+		hi = x1
+	}
+
+	dx := int64(x1 - x0)
+	dy := int64(abs(y1 - y0))
+	ystep := 1
+	if y0 > y1 {
+		ystep = -1
+	}
+
+	for x := lo; x <= hi; x++ {
+		// the y Bresenham reaches after k steps, computed directly so
+		// that the part of the line before the clip is skipped
+		k := int64(x - x0)
+		y := y0
+		if dx > 0 {
+			y += ystep * int((2*k*dy+dx)/(2*dx))
+		}
+		if steep {
+			c.plot(y, x)
+		} else {
+			c.plot(x, y)
+		}
+	}
+}
+
+// DrawHLine draws a horizontal line of w pixels starting at (x, y)
+func (c *Canvas) DrawHLine(x, y, w int) {
+	if w > 0 {
+		c.DrawLine(x, y, x+w-1, y)
+	}
+}
+
+// DrawVLine draws a vertical line of h pixels starting at (x, y)
+func (c *Canvas) DrawVLine(x, y, h int) {
+	if h > 0 {
+		c.DrawLine(x, y, x, y+h-1)
+	}
+}
+
+// DrawTriangle draws the outline of a triangle
+func (c *Canvas) DrawTriangle(x1, y1, x2, y2, x3, y3 int) {
+	c.DrawPolygon([]image.Point{{x1, y1}, {x2, y2}, {x3, y3}})
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,198 @@
+package main
+
+import (
+	"image"
+	"testing"
+)
+
+// checker fills buf with a pattern that has set and clear pixels everywhere
+func checker(buf *[6][LCDWIDTH]byte) {
+	for p := range buf {
+		for x := range buf[p] {
+			buf[p][x] = 0x55 << uint(x&1)
+		}
+	}
+}
+
+// pixels returns the set pixels of buf
+func pixels(buf *[6][LCDWIDTH]byte) map[image.Point]bool {
+	set := map[image.Point]bool{}
+	for y := 0; y < int(LCDHEIGHT); y++ {
+		for x := 0; x < int(LCDWIDTH); x++ {
+			if buf[y>>3][x]&(1<<uint(y&7)) != 0 {
+				set[image.Pt(x, y)] = true
+			}
+		}
+	}
+	return set
+}
+
+// onlyIn reports the first pixel of buf that is set outside r, or clear inside it
+func onlyIn(buf *[6][LCDWIDTH]byte, r image.Rectangle) (image.Point, bool) {
+	for y := 0; y < int(LCDHEIGHT); y++ {
+		for x := 0; x < int(LCDWIDTH); x++ {
+			p := image.Pt(x, y)
+			if (buf[y>>3][x]&(1<<uint(y&7)) != 0) != p.In(r) {
+				return p, false
+			}
+		}
+	}
+	return image.Point{}, true
+}
+
+var primitives = []struct {
+	name string
+	draw func(c *Canvas)
+}{
+	{"pixel", func(c *Canvas) { c.DrawPixel(5, 9) }},
+	{"line", func(c *Canvas) { c.DrawLine(2, 3, 70, 40) }},
+	{"wide line", func(c *Canvas) {
+		c.SetLineStyle(LineStyle{Width: 3, Cap: CapRound})
+		c.DrawLine(2, 3, 70, 40)
+	}},
+	{"rect", func(c *Canvas) { c.DrawRect(3, 5, 30, 20) }},
+	{"fill rect", func(c *Canvas) { c.FillRect(3, 5, 30, 20) }},
+	{"round rect", func(c *Canvas) { c.DrawRoundRect(3, 5, 30, 20, 6) }},
+	{"circle", func(c *Canvas) { c.FillCircle(40, 20, 12) }},
+	{"polygon", func(c *Canvas) {
+		c.FillPolygon([]image.Point{{10, 2}, {60, 10}, {30, 45}}, FillNonZero)
+	}},
+	{"text", func(c *Canvas) {
+		c.SetTextColor(TextTransparent)
+		c.DrawText(Font5x7, 3, 4, "Xy\n42")
+	}},
+	{"scaled text", func(c *Canvas) {
+		c.SetTextColor(TextTransparent)
+		c.SetTextScale(2, 3)
+		c.DrawText(Font8x8, 1, 1, "Ok")
+	}},
+	{"bitmap", func(c *Canvas) {
+		c.SetTransparent(true)
+		c.DrawBitmap(7, 3, 16, 16, []byte{
+			0xff, 0x81, 0x81, 0xff, 0, 0, 0x0f, 0xf0, 0xaa, 0x55, 0, 0, 0x18, 0x18, 0, 0x01,
+			0x01, 0x02, 0x04, 0x08, 0x10, 0x20, 0x40, 0x80, 0xff, 0, 0xff, 0, 0, 0, 0, 0xff,
+		})
+	}},
+}
+
+func TestDrawModes(t *testing.T) {
+	for _, prim := range primitives {
+		// the shape on its own tells which pixels each mode has to change
+		var shape [6][LCDWIDTH]byte
+		prim.draw(NewCanvas(&shape))
+		on := pixels(&shape)
+		if len(on) == 0 {
+			t.Fatalf("%s: draws nothing", prim.name)
+		}
+
+		for _, mode := range []DrawMode{DrawSet, DrawClear, DrawInvert} {
+			var buf, before [6][LCDWIDTH]byte
+			checker(&buf)
+			checker(&before)
+			c := NewCanvas(&buf)
+			c.SetMode(mode)
+			prim.draw(c)
+
+			was, is := pixels(&before), pixels(&buf)
+			for y := 0; y < int(LCDHEIGHT); y++ {
+				for x := 0; x < int(LCDWIDTH); x++ {
+					p := image.Pt(x, y)
+					want := was[p]
+					if on[p] {
+						switch mode {
+						case DrawSet:
+							want = true
+						case DrawClear:
+							want = false
+						case DrawInvert:
+							want = !want
+						}
+					}
+					if is[p] != want {
+						t.Fatalf("%s, mode %d: pixel %v is %v, want %v", prim.name, mode, p, is[p], want)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestClip(t *testing.T) {
+	tests := []struct {
+		name string
+		draw func(c *Canvas)
+		want image.Rectangle
+	}{
+		{"clip", func(c *Canvas) {
+			c.SetClip(image.Rect(10, 3, 30, 20))
+			c.FillRect(-5, -5, 100, 100)
+		}, image.Rect(10, 3, 30, 20)},
+		{"clip off the screen", func(c *Canvas) {
+			c.SetClip(image.Rect(-10, -10, 5, 5))
+			c.FillRect(-20, -20, 100, 100)
+		}, image.Rect(0, 0, 5, 5)},
+		{"reset", func(c *Canvas) {
+			c.SetClip(image.Rect(10, 3, 30, 20))
+			c.ResetClip()
+			c.FillRect(80, 40, 10, 10)
+		}, image.Rect(80, 40, 84, 48)},
+		{"sub canvas", func(c *Canvas) {
+			c.SubCanvas(image.Rect(10, 3, 30, 20)).FillRect(-5, -5, 100, 100)
+		}, image.Rect(10, 3, 30, 20)},
+		{"sub canvas origin", func(c *Canvas) {
+			c.SubCanvas(image.Rect(10, 3, 30, 20)).FillRect(2, 2, 4, 7)
+		}, image.Rect(12, 5, 16, 12)},
+		{"nested sub canvas", func(c *Canvas) {
+			s := c.SubCanvas(image.Rect(10, 3, 30, 20))
+			s.SetClip(image.Rect(0, 0, 5, 5))
+			s.SubCanvas(image.Rect(2, 2, 50, 50)).FillRect(0, 0, 50, 50)
+		}, image.Rect(12, 5, 15, 8)},
+		{"line", func(c *Canvas) {
+			c.SetClip(image.Rect(10, 0, 20, 48))
+			for y := 0; y < 48; y++ {
+				c.DrawLine(-100, y, 200, y)
+			}
+		}, image.Rect(10, 0, 20, 48)},
+		{"bitmap", func(c *Canvas) {
+			c.SetClip(image.Rect(4, 4, 12, 12))
+			b := NewBitmap(20, 20)
+			for y := 0; y < 20; y++ {
+				for x := 0; x < 20; x++ {
+					b.Set(x, y, true)
+				}
+			}
+			c.Blit(-3, -3, b, nil)
+		}, image.Rect(4, 4, 12, 12)},
+	}
+	for _, tt := range tests {
+		var buf [6][LCDWIDTH]byte
+		tt.draw(NewCanvas(&buf))
+		if p, ok := onlyIn(&buf, tt.want); !ok {
+			t.Errorf("%s: pixel %v is wrong, want only %v", tt.name, p, tt.want)
+		}
+	}
+}
+
+func TestMoveRect(t *testing.T) {
+	tests := []struct {
+		name string
+		src  image.Rectangle
+		dst  image.Point
+		want image.Rectangle
+	}{
+		{"right", image.Rect(0, 0, 10, 10), image.Pt(20, 0), image.Rect(20, 0, 30, 10)},
+		{"overlapping", image.Rect(0, 0, 10, 10), image.Pt(3, 5), image.Rect(3, 5, 13, 15)},
+		{"off the page", image.Rect(5, 3, 15, 12), image.Pt(40, 30), image.Rect(40, 30, 50, 39)},
+		{"reversed", image.Rectangle{image.Pt(10, 10), image.Pt(0, 0)}, image.Pt(20, 0), image.Rect(20, 0, 30, 10)},
+	}
+	for _, tt := range tests {
+		var buf [6][LCDWIDTH]byte
+		c := NewCanvas(&buf)
+		r := tt.src.Canon()
+		c.FillRect(r.Min.X, r.Min.Y, r.Dx(), r.Dy())
+		c.MoveRect(tt.src, tt.dst, WHITE)
+		if p, ok := onlyIn(&buf, tt.want); !ok {
+			t.Errorf("%s: pixel %v is wrong, want only %v", tt.name, p, tt.want)
+		}
+	}
+}
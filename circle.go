@@ -0,0 +1,133 @@
+package main
+
+import (
+	"math"
+)
+
+// DrawCircle draws the outline of a circle of radius r around (x0, y0)
+func (c *Canvas) DrawCircle(x0, y0, r int) {
+	if r < 0 {
+		return
+	}
+	c.strokeRounded(x0, y0, x0, y0, circleExtents(r))
+}
+
+// FillCircle fills a circle of radius r around (x0, y0)
+func (c *Canvas) FillCircle(x0, y0, r int) {
+	if r < 0 {
+		return
+	}
+	c.fillRounded(x0, y0, x0, y0, circleExtents(r))
+}
+
+// DrawEllipse draws the outline of an ellipse with radii rx and ry around (x0, y0)
+func (c *Canvas) DrawEllipse(x0, y0, rx, ry int) {
+	if rx < 0 || ry < 0 {
+		return
+	}
+	c.strokeRounded(x0, y0, x0, y0, ellipseExtents(rx, ry))
+}
+
+// FillEllipse fills an ellipse with radii rx and ry around (x0, y0)
+func (c *Canvas) FillEllipse(x0, y0, rx, ry int) {
+	if rx < 0 || ry < 0 {
+		return
+	}
+	c.fillRounded(x0, y0, x0, y0, ellipseExtents(rx, ry))
+}
+
+// DrawArc draws the part of the circle outline of radius r around (x0, y0)
+// from angle start to angle end. Angles are in degrees, 0 points to the
+// right and, as y grows downwards on the screen, angles grow clockwise.
+// Nothing is drawn when end equals start, an end before start goes round
+// through 360.
+func (c *Canvas) DrawArc(x0, y0, r int, start, end float64) {
+	if r < 0 || end == start {
+		return
+	}
+	sweep := end - start
+	if sweep < 0 {
+		sweep += 360 * math.Ceil(-sweep/360+1e-9)
+	}
+	start = math.Mod(start, 360)
+
+	ext := circleExtents(r)
+	for dy := -r; dy <= r; dy++ {
+		a, b := outlineRun(ext, abs(dy))
+		for dx := a; dx <= b; dx++ {
+			if inArc(dx, dy, start, sweep) {
+				c.plot(x0+dx, y0+dy)
+			}
+			if dx != 0 && inArc(-dx, dy, start, sweep) {
+				c.plot(x0-dx, y0+dy)
+			}
+		}
+	}
+}
+
+// inArc reports whether the direction (dx, dy) lies in the sweep degrees
+// clockwise from start
+func inArc(dx, dy int, start, sweep float64) bool {
+	if sweep >= 360 || (dx == 0 && dy == 0) {
+		return true
+	}
+	a := math.Atan2(float64(dy), float64(dx))*180/math.Pi - start
+	a = math.Mod(a, 360)
+	if a < 0 {
+		a += 360
+	}
+	return a <= sweep
+}
+
+// ellipseExtents runs the midpoint ellipse algorithm and returns, for every
+// row 0 <= dy <= ry away from the center, how far the ellipse reaches
+// horizontally, like circleExtents does for circles
+func ellipseExtents(rx, ry int) []int {
+	ext := make([]int, ry+1)
+	if ry == 0 {
+		ext[0] = rx
+		return ext
+	}
+
+	// all decision values are scaled by 4 to stay in integers
+	rx2, ry2 := int64(rx)*int64(rx), int64(ry)*int64(ry)
+	x, y := int64(0), int64(ry)
+	px, py := int64(0), 2*rx2*y
+
+	record := func() {
+		if int(x) > ext[y] {
+			ext[y] = int(x)
+		}
+	}
+
+	// region 1, the slope is flatter than -1
+	p := 4*ry2 - 4*rx2*int64(ry) + rx2
+	for px < py {
+		record()
+		x++
+		px += 2 * ry2
+		if p < 0 {
+			p += 4 * (ry2 + px)
+		} else {
+			y--
+			py -= 2 * rx2
+			p += 4 * (ry2 + px - py)
+		}
+	}
+
+	// region 2, the slope is steeper than -1
+	p = ry2*(2*x+1)*(2*x+1) + 4*rx2*(y-1)*(y-1) - 4*rx2*ry2
+	for y >= 0 {
+		record()
+		y--
+		py -= 2 * rx2
+		if p > 0 {
+			p += 4 * (rx2 - py)
+		} else {
+			x++
+			px += 2 * ry2
+			p += 4 * (rx2 - py + px)
+		}
+	}
+	return ext
+}
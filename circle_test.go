@@ -0,0 +1,37 @@
+package main
+
+import "testing"
+
+func TestDrawArc(t *testing.T) {
+	var full [6][LCDWIDTH]byte
+	NewCanvas(&full).DrawCircle(40, 24, 15)
+	circle := pixels(&full)
+	ring := len(circle)
+
+	tests := []struct {
+		name       string
+		start, end float64
+		min, max   int // number of pixels drawn
+	}{
+		{"empty", 90, 90, 0, 0},
+		{"full", 0, 360, ring, ring},
+		{"twice round", 30, 750, ring, ring},
+		{"quarter", 0, 90, ring/4 - 2, ring/4 + 2},
+		{"through 0", 315, 45, ring/4 - 2, ring/4 + 2},
+		{"negative", -45, 45, ring/4 - 2, ring/4 + 2},
+	}
+	for _, tt := range tests {
+		var buf [6][LCDWIDTH]byte
+		NewCanvas(&buf).DrawArc(40, 24, 15, tt.start, tt.end)
+		got := pixels(&buf)
+		if len(got) < tt.min || len(got) > tt.max {
+			t.Errorf("%s: %d pixels, want %d to %d", tt.name, len(got), tt.min, tt.max)
+		}
+		for p := range got {
+			if !circle[p] {
+				t.Errorf("%s: pixel %v is not on the circle", tt.name, p)
+				break
+			}
+		}
+	}
+}
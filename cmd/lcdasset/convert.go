@@ -0,0 +1,125 @@
+package main
+
+import (
+	"image"
+)
+
+// bitmap is a 1-bit image, true is a black (set) pixel
+type bitmap struct {
+	w, h int
+	pix  []bool
+}
+
+func (b *bitmap) at(x, y int) bool {
+	return b.pix[y*b.w+x]
+}
+
+// 4x4 Bayer matrix used by the ordered dither
+var bayer4 = [4][4]int{
+	{0, 8, 2, 10},
+	{12, 4, 14, 6},
+	{3, 11, 1, 9},
+	{15, 7, 13, 5},
+}
+
+// toBitmap converts img to 1-bit. Transparent pixels are treated as white.
+// dither is one of "none", "floyd" or "bayer".
+func toBitmap(img image.Image, threshold int, dither string, invert bool) *bitmap {
+	r := img.Bounds()
+	w, h := r.Dx(), r.Dy()
+	b := &bitmap{w: w, h: h, pix: make([]bool, w*h)}
+
+	luma := make([]int, w*h)
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			cr, cg, cb, ca := img.At(r.Min.X+x, r.Min.Y+y).RGBA()
+			// composite onto white, then use the color.GrayModel weights
+			cr += 0xffff - ca
+			cg += 0xffff - ca
+			cb += 0xffff - ca
+			luma[y*w+x] = int((19595*cr + 38470*cg + 7471*cb + 1<<15) >> 24)
+		}
+	}
+
+	switch dither {
+	case "floyd":
+		// Floyd-Steinberg error diffusion
+		for y := 0; y < h; y++ {
+			for x := 0; x < w; x++ {
+				old := luma[y*w+x]
+				black := old < threshold
+				b.pix[y*w+x] = black
+				e := old
+				if !black {
+					e = old - 255
+				}
+				spread := func(dx, dy, k int) {
+					nx, ny := x+dx, y+dy
+					if nx >= 0 && nx < w && ny < h {
+						luma[ny*w+nx] += e * k / 16
+					}
+				}
+				spread(1, 0, 7)
+				spread(-1, 1, 3)
+				spread(0, 1, 5)
+				spread(1, 1, 1)
+			}
+		}
+	case "bayer":
+		// ordered dither, threshold shifts the whole matrix
+		for y := 0; y < h; y++ {
+			for x := 0; x < w; x++ {
+				t := (bayer4[y%4][x%4]*2+1)*256/32 + threshold - 128
+				b.pix[y*w+x] = luma[y*w+x] < t
+			}
+		}
+	default:
+		for i, l := range luma {
+			b.pix[i] = l < threshold
+		}
+	}
+
+	if invert {
+		for i := range b.pix {
+			b.pix[i] = !b.pix[i]
+		}
+	}
+	return b
+}
+
+// pack converts b to the PCD8544 page layout: ceil(h/8) pages of w bytes,
+// bit n of a byte is the pixel in row page*8+n
+func pack(b *bitmap) []byte {
+	pages := (b.h + 7) / 8
+	data := make([]byte, pages*b.w)
+	for y := 0; y < b.h; y++ {
+		for x := 0; x < b.w; x++ {
+			if b.at(x, y) {
+				data[(y/8)*b.w+x] |= 1 << uint(y%8)
+			}
+		}
+	}
+	return data
+}
+
+// unpack is the inverse of pack, it reads a page layout table back into a bitmap
+func unpack(data []byte, w, h int) *bitmap {
+	b := &bitmap{w: w, h: h, pix: make([]bool, w*h)}
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			b.pix[y*w+x] = data[(y/8)*w+x]&(1<<uint(y%8)) != 0
+		}
+	}
+	return b
+}
+
+// image returns b as a black and white image, as the framebuffer would show it
+func (b *bitmap) image() *image.Gray {
+	img := newWhite(b.w, b.h)
+	for i, black := range b.pix {
+		if black {
+			img.Pix[i] = 0
+		}
+	}
+	return img
+}
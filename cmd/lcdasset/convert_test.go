@@ -0,0 +1,102 @@
+package main
+
+import (
+	"bytes"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+var tableByte = regexp.MustCompile(`0x([0-9A-F]{2}),`)
+
+// TestLogo converts the logo back into the table pcd8544.go used to hold
+func TestLogo(t *testing.T) {
+	src, err := ioutil.ReadFile("testdata/pi_logo.go.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var want []byte
+	for _, m := range tableByte.FindAllSubmatch(src, -1) {
+		v, _ := strconv.ParseUint(string(m[1]), 16, 8)
+		want = append(want, byte(v))
+	}
+	if len(want) != screenWidth*screenHeight/8 {
+		t.Fatalf("baseline table has %d bytes", len(want))
+	}
+
+	bm, data, err := convert("../../assets/logo/raspberry.png")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bm.w != screenWidth || bm.h != screenHeight {
+		t.Fatalf("size %dx%d", bm.w, bm.h)
+	}
+	for i := range want {
+		if data[i] != want[i] {
+			t.Fatalf("byte %d (page %d, x %d) is 0x%02X, want 0x%02X",
+				i, i/screenWidth, i%screenWidth, data[i], want[i])
+		}
+	}
+}
+
+func TestSprite(t *testing.T) {
+	defer func(s bool) { *sprite = s }(*sprite)
+	*sprite = true
+
+	// 3x10: a second page with only two rows in use
+	bm := &bitmap{w: 3, h: 10, pix: make([]bool, 30)}
+	bm.pix[0*3+0] = true
+	bm.pix[7*3+1] = true
+	bm.pix[9*3+2] = true
+	data := pack(bm)
+	if want := []byte{0x01, 0x80, 0x00, 0x00, 0x00, 0x02}; !bytes.Equal(data, want) {
+		t.Fatalf("pack = % X, want % X", data, want)
+	}
+
+	out, err := generate("icon.png", "icon", bm, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := parser.ParseFile(token.NewFileSet(), "icon.go", out, 0); err != nil {
+		t.Fatalf("generated source: %v\n%s", err, out)
+	}
+	for _, s := range []string{
+		"iconWidth  = 3",
+		"iconHeight = 10",
+		"// icon is a 3x10 sprite, 2 pages of 3 bytes",
+		"0x01, 0x80, 0x00, // page 0",
+		"0x00, 0x00, 0x02, // page 1",
+	} {
+		if !strings.Contains(string(out), s) {
+			t.Errorf("missing %q in\n%s", s, out)
+		}
+	}
+
+	back := unpack(data, bm.w, bm.h)
+	for i := range bm.pix {
+		if back.pix[i] != bm.pix[i] {
+			t.Errorf("unpack: pixel %d,%d is %v", i%bm.w, i/bm.w, back.pix[i])
+		}
+	}
+
+	// a sprite lower than 8 rows fits in a single page
+	img, err := decodeFile("testdata/arrow.pbm")
+	if err != nil {
+		t.Fatal(err)
+	}
+	bm = toBitmap(img, 128, "none", false)
+	out, err = generate("arrow.pbm", "arrow", bm, pack(bm))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := "// arrow is a 10x3 sprite, 1 page of 10 bytes"; !strings.Contains(string(out), s) {
+		t.Errorf("missing %q in\n%s", s, out)
+	}
+	if s := "0x01, 0x02, 0x02, 0x04, 0x04, 0x04, 0x04, 0x02, 0x02, 0x01, // page 0"; !strings.Contains(string(out), s) {
+		t.Errorf("missing %q in\n%s", s, out)
+	}
+}
@@ -0,0 +1,191 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"io"
+	"io/ioutil"
+	"regexp"
+	"strconv"
+)
+
+// decodePBM reads a portable bitmap, both the plain (P1) and the raw (P4) variant.
+// Set bits are black.
+func decodePBM(r io.Reader) (image.Image, error) {
+	br := bufio.NewReader(r)
+
+	magic, err := pbmToken(br)
+	if err != nil {
+		return nil, err
+	}
+	if magic != "P1" && magic != "P4" {
+		return nil, fmt.Errorf("pbm: unsupported magic %q", magic)
+	}
+
+	var size [2]int
+	for i := range size {
+		tok, err := pbmToken(br)
+		if err != nil {
+			return nil, err
+		}
+		size[i], err = strconv.Atoi(tok)
+		if err != nil || size[i] <= 0 {
+			return nil, fmt.Errorf("pbm: bad image size %q", tok)
+		}
+	}
+	w, h := size[0], size[1]
+	img := newWhite(w, h)
+
+	if magic == "P1" {
+		for y := 0; y < h; y++ {
+			for x := 0; x < w; x++ {
+				c, err := pbmBit(br)
+				if err != nil {
+					return nil, err
+				}
+				if c == '1' {
+					img.SetGray(x, y, color.Gray{0})
+				}
+			}
+		}
+		return img, nil
+	}
+
+	// P4: rows are packed MSB first and padded to a whole byte
+	row := make([]byte, (w+7)/8)
+	for y := 0; y < h; y++ {
+		if _, err := io.ReadFull(br, row); err != nil {
+			return nil, fmt.Errorf("pbm: %v", err)
+		}
+		for x := 0; x < w; x++ {
+			if row[x/8]&(0x80>>uint(x%8)) != 0 {
+				img.SetGray(x, y, color.Gray{0})
+			}
+		}
+	}
+	return img, nil
+}
+
+// pbmToken returns the next whitespace separated header token, skipping comments.
+// Exactly one whitespace byte after the token is consumed, as the format requires.
+func pbmToken(br *bufio.Reader) (string, error) {
+	var tok []byte
+	for {
+		c, err := br.ReadByte()
+		if err != nil {
+			if err == io.EOF && len(tok) > 0 {
+				return string(tok), nil
+			}
+			return "", errors.New("pbm: unexpected end of header")
+		}
+		switch {
+		case c == '#' && len(tok) == 0:
+			if _, err := br.ReadString('\n'); err != nil {
+				return "", errors.New("pbm: unexpected end of header")
+			}
+		case isSpace(c):
+			if len(tok) > 0 {
+				return string(tok), nil
+			}
+		default:
+			tok = append(tok, c)
+		}
+	}
+}
+
+// pbmBit returns the next '0' or '1' of a plain PBM raster
+func pbmBit(br *bufio.Reader) (byte, error) {
+	for {
+		c, err := br.ReadByte()
+		if err != nil {
+			return 0, errors.New("pbm: short raster")
+		}
+		switch {
+		case c == '0' || c == '1':
+			return c, nil
+		case c == '#':
+			if _, err := br.ReadString('\n'); err != nil {
+				return 0, errors.New("pbm: short raster")
+			}
+		case !isSpace(c):
+			return 0, fmt.Errorf("pbm: unexpected %q in raster", c)
+		}
+	}
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\v' || c == '\f'
+}
+
+var (
+	xbmDefine = regexp.MustCompile(`#define\s+\S*?_?(width|height)\s+(\d+)`)
+	xbmBits   = regexp.MustCompile(`(?s)_bits\s*\[\s*\]\s*=\s*\{(.*?)\}`)
+	xbmByte   = regexp.MustCompile(`0[xX][0-9a-fA-F]+|\d+`)
+)
+
+// decodeXBM reads an X11 bitmap. Rows are packed LSB first and padded to a
+// whole byte, set bits are black.
+func decodeXBM(r io.Reader) (image.Image, error) {
+	src, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var w, h int
+	for _, m := range xbmDefine.FindAllSubmatch(src, -1) {
+		n, _ := strconv.Atoi(string(m[2]))
+		if string(m[1]) == "width" {
+			w = n
+		} else {
+			h = n
+		}
+	}
+	if w <= 0 || h <= 0 {
+		return nil, errors.New("xbm: missing width or height")
+	}
+
+	m := xbmBits.FindSubmatch(src)
+	if m == nil {
+		return nil, errors.New("xbm: missing bits array")
+	}
+	var data []byte
+	for _, tok := range xbmByte.FindAll(m[1], -1) {
+		v, err := strconv.ParseUint(string(tok), 0, 8)
+		if err != nil {
+			return nil, fmt.Errorf("xbm: bad byte %q", tok)
+		}
+		data = append(data, byte(v))
+	}
+
+	stride := (w + 7) / 8
+	if len(data) < stride*h {
+		return nil, fmt.Errorf("xbm: need %d bytes, got %d", stride*h, len(data))
+	}
+
+	img := newWhite(w, h)
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			if data[y*stride+x/8]&(1<<uint(x%8)) != 0 {
+				img.SetGray(x, y, color.Gray{0})
+			}
+		}
+	}
+	return img, nil
+}
+
+// sniffXBM reports whether src looks like an XBM file
+func sniffXBM(src []byte) bool {
+	return bytes.Contains(src, []byte("#define")) && xbmBits.Match(src)
+}
+
+func newWhite(w, h int) *image.Gray {
+	img := image.NewGray(image.Rect(0, 0, w, h))
+	for i := range img.Pix {
+		img.Pix[i] = 0xff
+	}
+	return img
+}
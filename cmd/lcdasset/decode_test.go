@@ -0,0 +1,35 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+// all three fixtures hold the same 10x3 pattern, wider than one byte
+var arrow = []string{
+	"#........#",
+	".##....##.",
+	"...####...",
+}
+
+func TestDecode(t *testing.T) {
+	for _, file := range []string{"arrow.pbm", "arrow_raw.pbm", "arrow.xbm"} {
+		img, err := decodeFile(filepath.Join("testdata", file))
+		if err != nil {
+			t.Errorf("%s: %v", file, err)
+			continue
+		}
+		bm := toBitmap(img, 128, "none", false)
+		if bm.w != 10 || bm.h != 3 {
+			t.Errorf("%s: size %dx%d, want 10x3", file, bm.w, bm.h)
+			continue
+		}
+		for y, row := range arrow {
+			for x, c := range row {
+				if bm.at(x, y) != (c == '#') {
+					t.Errorf("%s: pixel %d,%d is %v", file, x, y, bm.at(x, y))
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,201 @@
+// Command lcdasset converts PNG, PBM and XBM images into Go source with
+// []byte tables in the PCD8544 page layout (6 pages of 84 bytes for a full
+// screen, or ceil(height/8) pages of width bytes for a sprite).
+//
+// It is meant to be run from go generate, for example
+//
+//	//go:generate go run ./cmd/lcdasset -name pi_logo -o pi_logo.go assets/pi_logo.png
+//	//go:generate go run ./cmd/lcdasset -sprite -dither floyd -name wifi_icon -o wifi_icon.go assets/wifi.png
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"image"
+	"image/png"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+)
+
+const (
+	screenWidth  = 84
+	screenHeight = 48
+)
+
+var (
+	output    = flag.String("o", "", "output file, stdout if empty")
+	pkg       = flag.String("pkg", "main", "package name of the generated file")
+	name      = flag.String("name", "", "variable name, derived from the input file if empty")
+	sprite    = flag.Bool("sprite", false, "emit a sprite of the image size with width/height constants instead of a full 84x48 screen")
+	threshold = flag.Int("threshold", 128, "pixels darker than this (0-255) become black")
+	dither    = flag.String("dither", "none", "dithering: none, floyd or bayer")
+	invert    = flag.Bool("invert", false, "invert the converted image")
+	preview   = flag.String("preview", "", "also write the converted image as PNG, read back from the generated table")
+)
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("lcdasset: ")
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: lcdasset [flags] image.{png,pbm,xbm}\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	switch *dither {
+	case "none", "floyd", "bayer":
+	default:
+		log.Fatalf("unknown dither %q", *dither)
+	}
+
+	input := flag.Arg(0)
+	bm, data, err := convert(input)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	varName := *name
+	if varName == "" {
+		varName = identifier(input)
+	}
+	src, err := generate(filepath.Base(input), varName, bm, data)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if *output == "" {
+		os.Stdout.Write(src)
+	} else if err := ioutil.WriteFile(*output, src, 0644); err != nil {
+		log.Fatal(err)
+	}
+
+	if *preview != "" {
+		// show what the table holds, not what was converted
+		var buf bytes.Buffer
+		if err := png.Encode(&buf, unpack(data, bm.w, bm.h).image()); err != nil {
+			log.Fatal(err)
+		}
+		if err := ioutil.WriteFile(*preview, buf.Bytes(), 0644); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
+
+// convert decodes input and converts it with the flags to a bitmap and its
+// table in the page layout
+func convert(input string) (*bitmap, []byte, error) {
+	img, err := decodeFile(input)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	bm := toBitmap(img, *threshold, *dither, *invert)
+	if !*sprite {
+		if bm.w > screenWidth || bm.h > screenHeight {
+			return nil, nil, fmt.Errorf("%s is %dx%d, a full screen image must fit in %dx%d (or use -sprite)",
+				input, bm.w, bm.h, screenWidth, screenHeight)
+		}
+		bm = pad(bm, screenWidth, screenHeight)
+	}
+	return bm, pack(bm), nil
+}
+
+// decodeFile picks the decoder from the file extension, falling back to sniffing
+func decodeFile(path string) (image.Image, error) {
+	src, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var img image.Image
+	switch ext := strings.ToLower(filepath.Ext(path)); {
+	case ext == ".pbm" || bytes.HasPrefix(src, []byte("P1")) || bytes.HasPrefix(src, []byte("P4")):
+		img, err = decodePBM(bytes.NewReader(src))
+	case ext == ".xbm" || sniffXBM(src):
+		img, err = decodeXBM(bytes.NewReader(src))
+	default:
+		img, err = png.Decode(bytes.NewReader(src))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	return img, nil
+}
+
+// pad places b in the top left corner of a white w*h bitmap
+func pad(b *bitmap, w, h int) *bitmap {
+	out := &bitmap{w: w, h: h, pix: make([]bool, w*h)}
+	for y := 0; y < b.h; y++ {
+		copy(out.pix[y*w:y*w+b.w], b.pix[y*b.w:(y+1)*b.w])
+	}
+	return out
+}
+
+// identifier turns a file name like "wifi-3.png" into "wifi_3"
+func identifier(path string) string {
+	base := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	id := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+		return '_'
+	}, base)
+	if id == "" || unicode.IsDigit(rune(id[0])) {
+		id = "img_" + id
+	}
+	return id
+}
+
+func generate(source, varName string, bm *bitmap, data []byte) ([]byte, error) {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by lcdasset from %s; DO NOT EDIT.\n\n", source)
+	fmt.Fprintf(&b, "package %s\n\n", *pkg)
+
+	if *sprite {
+		fmt.Fprintf(&b, "const (\n")
+		fmt.Fprintf(&b, "\t%sWidth  = %d\n", varName, bm.w)
+		fmt.Fprintf(&b, "\t%sHeight = %d\n", varName, bm.h)
+		fmt.Fprintf(&b, ")\n\n")
+		pages := "pages"
+		if (bm.h+7)/8 == 1 {
+			pages = "page"
+		}
+		fmt.Fprintf(&b, "// %s is a %dx%d sprite, %d %s of %d bytes\n", varName, bm.w, bm.h, (bm.h+7)/8, pages, bm.w)
+	} else {
+		fmt.Fprintf(&b, "// %s is a full screen image, 6 pages of 84 bytes\n", varName)
+	}
+
+	fmt.Fprintf(&b, "var %s []byte = []byte{\n", varName)
+	for p := 0; p*bm.w < len(data); p++ {
+		page := data[p*bm.w : (p+1)*bm.w]
+		for i := 0; i < len(page); i += 12 {
+			end := i + 12
+			if end > len(page) {
+				end = len(page)
+			}
+			b.WriteString("\t")
+			for j, v := range page[i:end] {
+				if j > 0 {
+					b.WriteString(" ")
+				}
+				fmt.Fprintf(&b, "0x%02X,", v)
+			}
+			if i == 0 {
+				fmt.Fprintf(&b, " // page %d", p)
+			}
+			b.WriteString("\n")
+		}
+	}
+	b.WriteString("}\n")
+
+	return format.Source(b.Bytes())
+}
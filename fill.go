@@ -0,0 +1,114 @@
+package main
+
+import (
+	"image"
+)
+
+// Pattern is an 8x8 fill pattern, byte n is row n and bit 0 the leftmost
+// column. Patterns are anchored to the screen, so neighbouring shapes line up.
+type Pattern [8]byte
+
+var (
+	PatternSolid      = Pattern{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}
+	Pattern75         = Pattern{0xee, 0xbb, 0xee, 0xbb, 0xee, 0xbb, 0xee, 0xbb}
+	PatternChecker    = Pattern{0x55, 0xaa, 0x55, 0xaa, 0x55, 0xaa, 0x55, 0xaa} // 50%
+	Pattern25         = Pattern{0x11, 0x44, 0x11, 0x44, 0x11, 0x44, 0x11, 0x44}
+	PatternDots       = Pattern{0x11, 0x00, 0x44, 0x00, 0x11, 0x00, 0x44, 0x00} // 12.5%
+	PatternHatch      = Pattern{0x11, 0x88, 0x44, 0x22, 0x11, 0x88, 0x44, 0x22} // diagonal /
+	PatternBackHatch  = Pattern{0x11, 0x22, 0x44, 0x88, 0x11, 0x22, 0x44, 0x88} // diagonal \
+	PatternCrossHatch = Pattern{0x11, 0xaa, 0x44, 0xaa, 0x11, 0xaa, 0x44, 0xaa}
+	PatternHorizontal = Pattern{0xff, 0x00, 0xff, 0x00, 0xff, 0x00, 0xff, 0x00}
+	PatternVertical   = Pattern{0x55, 0x55, 0x55, 0x55, 0x55, 0x55, 0x55, 0x55}
+)
+
+// column returns column x of the pattern as a page byte
+func (pat Pattern) column(x int) byte {
+	var b byte
+	for i, row := range pat {
+		if row&(1<<uint(x&7)) != 0 {
+			b |= 1 << uint(i)
+		}
+	}
+	return b
+}
+
+// FillPattern returns the current fill pattern
+func (c *Canvas) FillPattern() Pattern {
+	return c.pattern
+}
+
+// SetFillPattern sets the pattern FillRect, FillRoundRect, FillCircle,
+// FillEllipse, FillTriangle, FillPolygon, FillPath and FloodFill use.
+// Pixels where the pattern is set are drawn with the draw mode, the others
+// are background, like the background of a bitmap (see SetTransparent).
+func (c *Canvas) SetFillPattern(pat Pattern) {
+	c.pattern = pat
+}
+
+// fillByte fills the pixels of page p in column x selected by mask
+func (c *Canvas) fillByte(x, p int, mask byte) {
+	// rotate the pattern so it stays anchored to the screen in a view that
+	// does not start on a page boundary
+	col := c.pattern.column(x + c.origin.X)
+	shift := uint(c.origin.Y & 7)
+	col = col>>shift | col<<(8-shift)
+	c.writeByte(x, p, mask&col, mask)
+}
+
+// fillMask fills the pixels set in mask, a page byte at a time. mask is in
+// buffer coordinates, see maskCanvas.
+func (c *Canvas) fillMask(mask *[6][LCDWIDTH]byte) {
+	for p := range mask {
+		for x := range mask[p] {
+			if bits := mask[p][x]; bits != 0 {
+				c.put(x, p, bits&c.pattern.column(x), bits)
+			}
+		}
+	}
+}
+
+// FloodFill fills the area of clear pixels around (x, y), bounded by set
+// pixels and the clip rectangle. Nothing happens if (x, y) is set.
+func (c *Canvas) FloodFill(x, y int) {
+	var mask [6][LCDWIDTH]byte
+	m := c.maskCanvas(&mask)
+	clip := c.Clip()
+	free := func(x, y int) bool {
+		return (image.Point{x, y}).In(clip) && !c.Pixel(x, y) && !m.Pixel(x, y)
+	}
+	if !free(x, y) {
+		return
+	}
+
+	// scanline fill: fill a whole run, then look for runs above and below
+	stack := []image.Point{{x, y}}
+	for len(stack) > 0 {
+		pt := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if !free(pt.X, pt.Y) {
+			continue
+		}
+
+		l, r := pt.X, pt.X
+		for free(l-1, pt.Y) {
+			l--
+		}
+		for free(r+1, pt.Y) {
+			r++
+		}
+		m.drawLine(l, pt.Y, r, pt.Y)
+
+		for _, ny := range []int{pt.Y - 1, pt.Y + 1} {
+			inRun := false
+			for i := l; i <= r; i++ {
+				if !free(i, ny) {
+					inRun = false
+				} else if !inRun {
+					stack = append(stack, image.Point{i, ny})
+					inRun = true
+				}
+			}
+		}
+	}
+	c.fillMask(&mask)
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"image"
+)
+
+// Glyph is the bitmap of one character of a Font
+type Glyph struct {
+	// set pixels are drawn in the text colour, nil for a blank glyph
+	Bitmap *Bitmap
+	// the top left corner of Bitmap relative to the pen position on the
+	// baseline, so Offset.Y is negative for pixels above the baseline
+	Offset image.Point
+	// how far the pen moves on to the next glyph
+	Advance int
+}
+
+// FontMetrics are the vertical measures of a font, in pixels
+type FontMetrics struct {
+	Height int // distance from one line to the next
+	Ascent int // distance from the top of a line to the baseline
+}
+
+// Font maps runes to glyphs. Glyphs may have different sizes and advances.
+type Font interface {
+	// Glyph returns the glyph of r, ok is false if the font does not have one
+	Glyph(r rune) (g Glyph, ok bool)
+	Metrics() FontMetrics
+}
+
+// BitmapFont is a Font with a fixed set of glyphs
+type BitmapFont struct {
+	FontMetrics
+	Glyphs map[rune]Glyph
+}
+
+func (f *BitmapFont) Glyph(r rune) (Glyph, bool) {
+	g, ok := f.Glyphs[r]
+	return g, ok
+}
+
+func (f *BitmapFont) Metrics() FontMetrics {
+	return f.FontMetrics
+}
+
+// newPageFont builds a font from glyphs in page format (see Bitmap) that
+// are h pixels high and start at the top of the line. Every glyph is
+// followed by gap blank columns.
+func newPageFont(m FontMetrics, h, gap int, glyphs map[rune][]byte) *BitmapFont {
+	f := &BitmapFont{FontMetrics: m, Glyphs: make(map[rune]Glyph, len(glyphs))}
+	pages := (h + 7) / 8
+	for r, data := range glyphs {
+		w := len(data) / pages
+		f.Glyphs[r] = Glyph{
+			Bitmap:  &Bitmap{Width: w, Height: h, Format: PageFormat, Data: data},
+			Offset:  image.Pt(0, -m.Ascent),
+			Advance: w + gap,
+		}
+	}
+	return f
+}
+
+// dictFont is the original 5x7 font in dict
+type dictFont struct {
+	*ByteDictionary
+}
+
+func (f dictFont) Glyph(r rune) (Glyph, bool) {
+	if r < 0 || r > 0xff || !f.Has(byte(r)) {
+		return Glyph{}, false
+	}
+	g := f.Get(byte(r))
+	return Glyph{
+		// the 8th row is the gap to the next line
+		Bitmap:  &Bitmap{Width: 5, Height: 8, Format: PageFormat, Data: g[:]},
+		Offset:  image.Pt(0, -7),
+		Advance: 6,
+	}, true
+}
+
+func (f dictFont) Metrics() FontMetrics {
+	return FontMetrics{Height: 8, Ascent: 7}
+}
+
+var (
+	// Font3x5 is a tiny font of capitals, digits and punctuation, 21
+	// characters fit on a line
+	Font3x5 Font = newFont3x5()
+	// Font5x7 is the original font of LCDDrawString, 14 characters per line
+	Font5x7 Font = dictFont{dict}
+	// Font8x8 is a bold ASCII font with descenders
+	Font8x8 Font = newFont8x8()
+	// Font10x16 has big digits and the characters . : - + % ° and space,
+	// for numbers that can be read from across a room
+	Font10x16 Font = newPageFont(FontMetrics{Height: 16, Ascent: 14}, 16, 1, font10x16Glyphs)
+)
+
+func newFont3x5() *BitmapFont {
+	f := newPageFont(FontMetrics{Height: 6, Ascent: 5}, 5, 1, font3x5Glyphs)
+	for r := 'a'; r <= 'z'; r++ {
+		f.Glyphs[r] = f.Glyphs[r-'a'+'A']
+	}
+	return f
+}
+
+func newFont8x8() *BitmapFont {
+	glyphs := make(map[rune][]byte, len(font8x8Glyphs))
+	for i := range font8x8Glyphs {
+		glyphs[rune(0x20+i)] = font8x8Glyphs[i][:]
+	}
+	// the glyphs have their own gap
+	return newPageFont(FontMetrics{Height: 8, Ascent: 7}, 8, 0, glyphs)
+}
@@ -0,0 +1,197 @@
+package main
+
+// the glyphs of Font3x5 as columns of 5 pixels, bit 0 is the top row.
+// Glyphs are 1 to 3 columns wide, lowercase letters use the capitals.
+var font3x5Glyphs = map[rune][]byte{
+	' ':  {0x00, 0x00, 0x00},
+	'!':  {0x17},
+	'"':  {0x03, 0x00, 0x03},
+	'#':  {0x1f, 0x0a, 0x1f},
+	'$':  {0x12, 0x1f, 0x09},
+	'%':  {0x19, 0x04, 0x13},
+	'&':  {0x0a, 0x15, 0x1a},
+	'\'': {0x03},
+	'(':  {0x0e, 0x11},
+	')':  {0x11, 0x0e},
+	'*':  {0x0a, 0x04, 0x0a},
+	'+':  {0x04, 0x0e, 0x04},
+	',':  {0x10, 0x08},
+	'-':  {0x04, 0x04, 0x04},
+	'.':  {0x10},
+	'/':  {0x18, 0x04, 0x03},
+	'0':  {0x1f, 0x11, 0x1f},
+	'1':  {0x12, 0x1f, 0x10},
+	'2':  {0x1d, 0x15, 0x17},
+	'3':  {0x11, 0x15, 0x1f},
+	'4':  {0x07, 0x04, 0x1f},
+	'5':  {0x17, 0x15, 0x1d},
+	'6':  {0x1f, 0x15, 0x1d},
+	'7':  {0x01, 0x19, 0x07},
+	'8':  {0x1f, 0x15, 0x1f},
+	'9':  {0x17, 0x15, 0x1f},
+	':':  {0x0a},
+	';':  {0x10, 0x0a},
+	'<':  {0x04, 0x0a, 0x11},
+	'=':  {0x0a, 0x0a, 0x0a},
+	'>':  {0x11, 0x0a, 0x04},
+	'?':  {0x01, 0x15, 0x07},
+	'@':  {0x0e, 0x15, 0x16},
+	'A':  {0x1e, 0x05, 0x1e},
+	'B':  {0x1f, 0x15, 0x0a},
+	'C':  {0x0e, 0x11, 0x11},
+	'D':  {0x1f, 0x11, 0x0e},
+	'E':  {0x1f, 0x15, 0x11},
+	'F':  {0x1f, 0x05, 0x01},
+	'G':  {0x0e, 0x11, 0x1d},
+	'H':  {0x1f, 0x04, 0x1f},
+	'I':  {0x11, 0x1f, 0x11},
+	'J':  {0x08, 0x10, 0x0f},
+	'K':  {0x1f, 0x04, 0x1b},
+	'L':  {0x1f, 0x10, 0x10},
+	'M':  {0x1f, 0x06, 0x1f},
+	'N':  {0x1f, 0x01, 0x1e},
+	'O':  {0x0e, 0x11, 0x0e},
+	'P':  {0x1f, 0x05, 0x02},
+	'Q':  {0x0e, 0x19, 0x16},
+	'R':  {0x1f, 0x05, 0x1a},
+	'S':  {0x12, 0x15, 0x09},
+	'T':  {0x01, 0x1f, 0x01},
+	'U':  {0x1f, 0x10, 0x1f},
+	'V':  {0x07, 0x18, 0x07},
+	'W':  {0x1f, 0x0c, 0x1f},
+	'X':  {0x1b, 0x04, 0x1b},
+	'Y':  {0x03, 0x1c, 0x03},
+	'Z':  {0x19, 0x15, 0x13},
+	'[':  {0x1f, 0x11},
+	'\\': {0x03, 0x04, 0x18},
+	']':  {0x11, 0x1f},
+	'^':  {0x02, 0x01, 0x02},
+	'_':  {0x10, 0x10, 0x10},
+	'`':  {0x01, 0x02},
+	'{':  {0x04, 0x1f, 0x11},
+	'|':  {0x1f},
+	'}':  {0x11, 0x1f, 0x04},
+	'~':  {0x06, 0x04, 0x0c},
+	'°':  {0x02, 0x05, 0x02},
+}
+
+// the glyphs of Font8x8 from 0x20 to 0x7e as page format columns
+var font8x8Glyphs = [][8]byte{
+	{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}, // 20 space
+	{0x00, 0x00, 0x06, 0x5f, 0x5f, 0x06, 0x00, 0x00}, // 21 !
+	{0x00, 0x03, 0x03, 0x00, 0x03, 0x03, 0x00, 0x00}, // 22 "
+	{0x14, 0x7f, 0x7f, 0x14, 0x7f, 0x7f, 0x14, 0x00}, // 23 #
+	{0x24, 0x2e, 0x6b, 0x6b, 0x3a, 0x12, 0x00, 0x00}, // 24 $
+	{0x46, 0x66, 0x30, 0x18, 0x0c, 0x66, 0x62, 0x00}, // 25 %
+	{0x30, 0x7a, 0x4f, 0x5d, 0x37, 0x7a, 0x48, 0x00}, // 26 &
+	{0x04, 0x07, 0x03, 0x00, 0x00, 0x00, 0x00, 0x00}, // 27 '
+	{0x00, 0x1c, 0x3e, 0x63, 0x41, 0x00, 0x00, 0x00}, // 28 (
+	{0x00, 0x41, 0x63, 0x3e, 0x1c, 0x00, 0x00, 0x00}, // 29 )
+	{0x08, 0x2a, 0x3e, 0x1c, 0x1c, 0x3e, 0x2a, 0x08}, // 2a *
+	{0x08, 0x08, 0x3e, 0x3e, 0x08, 0x08, 0x00, 0x00}, // 2b +
+	{0x00, 0x80, 0xe0, 0x60, 0x00, 0x00, 0x00, 0x00}, // 2c ,
+	{0x08, 0x08, 0x08, 0x08, 0x08, 0x08, 0x00, 0x00}, // 2d -
+	{0x00, 0x00, 0x60, 0x60, 0x00, 0x00, 0x00, 0x00}, // 2e .
+	{0x60, 0x30, 0x18, 0x0c, 0x06, 0x03, 0x01, 0x00}, // 2f /
+	{0x3e, 0x7f, 0x71, 0x59, 0x4d, 0x7f, 0x3e, 0x00}, // 30 0
+	{0x40, 0x42, 0x7f, 0x7f, 0x40, 0x40, 0x00, 0x00}, // 31 1
+	{0x62, 0x73, 0x59, 0x49, 0x6f, 0x66, 0x00, 0x00}, // 32 2
+	{0x22, 0x63, 0x49, 0x49, 0x7f, 0x36, 0x00, 0x00}, // 33 3
+	{0x18, 0x1c, 0x16, 0x53, 0x7f, 0x7f, 0x50, 0x00}, // 34 4
+	{0x27, 0x67, 0x45, 0x45, 0x7d, 0x39, 0x00, 0x00}, // 35 5
+	{0x3c, 0x7e, 0x4b, 0x49, 0x79, 0x30, 0x00, 0x00}, // 36 6
+	{0x03, 0x03, 0x71, 0x79, 0x0f, 0x07, 0x00, 0x00}, // 37 7
+	{0x36, 0x7f, 0x49, 0x49, 0x7f, 0x36, 0x00, 0x00}, // 38 8
+	{0x06, 0x4f, 0x49, 0x69, 0x3f, 0x1e, 0x00, 0x00}, // 39 9
+	{0x00, 0x00, 0x66, 0x66, 0x00, 0x00, 0x00, 0x00}, // 3a :
+	{0x00, 0x80, 0xe6, 0x66, 0x00, 0x00, 0x00, 0x00}, // 3b ;
+	{0x08, 0x1c, 0x36, 0x63, 0x41, 0x00, 0x00, 0x00}, // 3c <
+	{0x24, 0x24, 0x24, 0x24, 0x24, 0x24, 0x00, 0x00}, // 3d =
+	{0x00, 0x41, 0x63, 0x36, 0x1c, 0x08, 0x00, 0x00}, // 3e >
+	{0x02, 0x03, 0x51, 0x59, 0x0f, 0x06, 0x00, 0x00}, // 3f ?
+	{0x3e, 0x7f, 0x41, 0x5d, 0x5d, 0x1f, 0x1e, 0x00}, // 40 @
+	{0x7c, 0x7e, 0x13, 0x13, 0x7e, 0x7c, 0x00, 0x00}, // 41 A
+	{0x41, 0x7f, 0x7f, 0x49, 0x49, 0x7f, 0x36, 0x00}, // 42 B
+	{0x1c, 0x3e, 0x63, 0x41, 0x41, 0x63, 0x22, 0x00}, // 43 C
+	{0x41, 0x7f, 0x7f, 0x41, 0x63, 0x3e, 0x1c, 0x00}, // 44 D
+	{0x41, 0x7f, 0x7f, 0x49, 0x5d, 0x41, 0x63, 0x00}, // 45 E
+	{0x41, 0x7f, 0x7f, 0x49, 0x1d, 0x01, 0x03, 0x00}, // 46 F
+	{0x1c, 0x3e, 0x63, 0x41, 0x51, 0x73, 0x72, 0x00}, // 47 G
+	{0x7f, 0x7f, 0x08, 0x08, 0x7f, 0x7f, 0x00, 0x00}, // 48 H
+	{0x00, 0x41, 0x7f, 0x7f, 0x41, 0x00, 0x00, 0x00}, // 49 I
+	{0x30, 0x70, 0x40, 0x41, 0x7f, 0x3f, 0x01, 0x00}, // 4a J
+	{0x41, 0x7f, 0x7f, 0x08, 0x1c, 0x77, 0x63, 0x00}, // 4b K
+	{0x41, 0x7f, 0x7f, 0x41, 0x40, 0x60, 0x70, 0x00}, // 4c L
+	{0x7f, 0x7f, 0x0e, 0x1c, 0x0e, 0x7f, 0x7f, 0x00}, // 4d M
+	{0x7f, 0x7f, 0x06, 0x0c, 0x18, 0x7f, 0x7f, 0x00}, // 4e N
+	{0x1c, 0x3e, 0x63, 0x41, 0x63, 0x3e, 0x1c, 0x00}, // 4f O
+	{0x41, 0x7f, 0x7f, 0x49, 0x09, 0x0f, 0x06, 0x00}, // 50 P
+	{0x1e, 0x3f, 0x21, 0x71, 0x7f, 0x5e, 0x00, 0x00}, // 51 Q
+	{0x41, 0x7f, 0x7f, 0x09, 0x19, 0x7f, 0x66, 0x00}, // 52 R
+	{0x26, 0x6f, 0x4d, 0x59, 0x73, 0x32, 0x00, 0x00}, // 53 S
+	{0x03, 0x41, 0x7f, 0x7f, 0x41, 0x03, 0x00, 0x00}, // 54 T
+	{0x7f, 0x7f, 0x40, 0x40, 0x7f, 0x7f, 0x00, 0x00}, // 55 U
+	{0x1f, 0x3f, 0x60, 0x60, 0x3f, 0x1f, 0x00, 0x00}, // 56 V
+	{0x7f, 0x7f, 0x30, 0x18, 0x30, 0x7f, 0x7f, 0x00}, // 57 W
+	{0x43, 0x67, 0x3c, 0x18, 0x3c, 0x67, 0x43, 0x00}, // 58 X
+	{0x07, 0x4f, 0x78, 0x78, 0x4f, 0x07, 0x00, 0x00}, // 59 Y
+	{0x47, 0x63, 0x71, 0x59, 0x4d, 0x67, 0x73, 0x00}, // 5a Z
+	{0x00, 0x7f, 0x7f, 0x41, 0x41, 0x00, 0x00, 0x00}, // 5b [
+	{0x01, 0x03, 0x06, 0x0c, 0x18, 0x30, 0x60, 0x00}, // 5c \
+	{0x00, 0x41, 0x41, 0x7f, 0x7f, 0x00, 0x00, 0x00}, // 5d ]
+	{0x08, 0x0c, 0x06, 0x03, 0x06, 0x0c, 0x08, 0x00}, // 5e ^
+	{0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80}, // 5f _
+	{0x00, 0x00, 0x03, 0x07, 0x04, 0x00, 0x00, 0x00}, // 60 `
+	{0x20, 0x74, 0x54, 0x54, 0x3c, 0x78, 0x40, 0x00}, // 61 a
+	{0x41, 0x7f, 0x3f, 0x48, 0x48, 0x78, 0x30, 0x00}, // 62 b
+	{0x38, 0x7c, 0x44, 0x44, 0x6c, 0x28, 0x00, 0x00}, // 63 c
+	{0x30, 0x78, 0x48, 0x49, 0x3f, 0x7f, 0x40, 0x00}, // 64 d
+	{0x38, 0x7c, 0x54, 0x54, 0x5c, 0x18, 0x00, 0x00}, // 65 e
+	{0x48, 0x7e, 0x7f, 0x49, 0x03, 0x02, 0x00, 0x00}, // 66 f
+	{0x98, 0xbc, 0xa4, 0xa4, 0xf8, 0x7c, 0x04, 0x00}, // 67 g
+	{0x41, 0x7f, 0x7f, 0x08, 0x04, 0x7c, 0x78, 0x00}, // 68 h
+	{0x00, 0x44, 0x7d, 0x7d, 0x40, 0x00, 0x00, 0x00}, // 69 i
+	{0x60, 0xe0, 0x80, 0x80, 0xfd, 0x7d, 0x00, 0x00}, // 6a j
+	{0x41, 0x7f, 0x7f, 0x10, 0x38, 0x6c, 0x44, 0x00}, // 6b k
+	{0x00, 0x41, 0x7f, 0x7f, 0x40, 0x00, 0x00, 0x00}, // 6c l
+	{0x7c, 0x7c, 0x18, 0x38, 0x1c, 0x7c, 0x78, 0x00}, // 6d m
+	{0x7c, 0x7c, 0x04, 0x04, 0x7c, 0x78, 0x00, 0x00}, // 6e n
+	{0x38, 0x7c, 0x44, 0x44, 0x7c, 0x38, 0x00, 0x00}, // 6f o
+	{0x84, 0xfc, 0xf8, 0xa4, 0x24, 0x3c, 0x18, 0x00}, // 70 p
+	{0x18, 0x3c, 0x24, 0xa4, 0xf8, 0xfc, 0x84, 0x00}, // 71 q
+	{0x44, 0x7c, 0x78, 0x4c, 0x04, 0x1c, 0x18, 0x00}, // 72 r
+	{0x48, 0x5c, 0x54, 0x54, 0x74, 0x24, 0x00, 0x00}, // 73 s
+	{0x00, 0x04, 0x3e, 0x7f, 0x44, 0x24, 0x00, 0x00}, // 74 t
+	{0x3c, 0x7c, 0x40, 0x40, 0x3c, 0x7c, 0x40, 0x00}, // 75 u
+	{0x1c, 0x3c, 0x60, 0x60, 0x3c, 0x1c, 0x00, 0x00}, // 76 v
+	{0x3c, 0x7c, 0x70, 0x38, 0x70, 0x7c, 0x3c, 0x00}, // 77 w
+	{0x44, 0x6c, 0x38, 0x10, 0x38, 0x6c, 0x44, 0x00}, // 78 x
+	{0x9c, 0xbc, 0xa0, 0xa0, 0xfc, 0x7c, 0x00, 0x00}, // 79 y
+	{0x4c, 0x64, 0x74, 0x5c, 0x4c, 0x64, 0x00, 0x00}, // 7a z
+	{0x08, 0x08, 0x3e, 0x77, 0x41, 0x41, 0x00, 0x00}, // 7b {
+	{0x00, 0x00, 0x00, 0x77, 0x77, 0x00, 0x00, 0x00}, // 7c |
+	{0x41, 0x41, 0x77, 0x3e, 0x08, 0x08, 0x00, 0x00}, // 7d }
+	{0x02, 0x03, 0x01, 0x03, 0x02, 0x03, 0x01, 0x00}, // 7e ~
+}
+
+// the glyphs of Font10x16, two pages of columns each: the top 8 rows of
+// every column, then the bottom 8
+var font10x16Glyphs = map[rune][]byte{
+	'0': {0xfc, 0xfe, 0x07, 0x03, 0x03, 0x03, 0x03, 0x07, 0xfe, 0xfc, 0x0f, 0x1f, 0x38, 0x30, 0x30, 0x30, 0x30, 0x38, 0x1f, 0x0f},
+	'1': {0x00, 0x08, 0x0c, 0x06, 0xff, 0xff, 0x00, 0x00, 0x00, 0x00, 0x00, 0x30, 0x30, 0x30, 0x3f, 0x3f, 0x30, 0x30, 0x30, 0x00},
+	'2': {0x0c, 0x0e, 0x07, 0x03, 0x83, 0x83, 0xc3, 0xe7, 0x7e, 0x3c, 0x3c, 0x3e, 0x37, 0x33, 0x31, 0x31, 0x30, 0x30, 0x30, 0x30},
+	'3': {0x0c, 0x0e, 0x07, 0xc3, 0xc3, 0xc3, 0xc3, 0xe7, 0x3e, 0x3c, 0x0c, 0x1c, 0x38, 0x30, 0x30, 0x30, 0x30, 0x39, 0x1f, 0x0f},
+	'4': {0xc0, 0xe0, 0xb0, 0x98, 0x8c, 0x86, 0x83, 0xff, 0xff, 0x80, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x3f, 0x3f, 0x01},
+	'5': {0x7f, 0x7f, 0x63, 0x33, 0x33, 0x33, 0x33, 0x73, 0xe3, 0xc3, 0x0c, 0x1c, 0x38, 0x30, 0x30, 0x30, 0x30, 0x38, 0x1f, 0x0f},
+	'6': {0xf8, 0xfc, 0xce, 0x67, 0x63, 0x63, 0x63, 0xe3, 0xc0, 0x80, 0x0f, 0x1f, 0x38, 0x30, 0x30, 0x30, 0x30, 0x38, 0x1f, 0x0f},
+	'7': {0x03, 0x03, 0x03, 0x03, 0xc3, 0xe3, 0x73, 0x3b, 0x1f, 0x0f, 0x00, 0x00, 0x00, 0x3f, 0x3f, 0x01, 0x00, 0x00, 0x00, 0x00},
+	'8': {0x3c, 0xfe, 0xe7, 0xc3, 0xc3, 0xc3, 0xc3, 0xe7, 0xfe, 0x3c, 0x0f, 0x1f, 0x39, 0x30, 0x30, 0x30, 0x30, 0x39, 0x1f, 0x0f},
+	'9': {0x7c, 0xfe, 0xc7, 0x83, 0x83, 0x83, 0x83, 0xc7, 0xfe, 0xfc, 0x00, 0x00, 0x31, 0x31, 0x31, 0x31, 0x39, 0x1c, 0x0f, 0x07},
+	'.': {0x00, 0x00, 0x00, 0x00, 0x00, 0x30, 0x30, 0x00},
+	':': {0x00, 0x18, 0x18, 0x00, 0x00, 0x06, 0x06, 0x00},
+	'-': {0x00, 0xc0, 0xc0, 0xc0, 0xc0, 0xc0, 0xc0, 0xc0, 0xc0, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00},
+	'+': {0x00, 0xc0, 0xc0, 0xc0, 0xf8, 0xf8, 0xc0, 0xc0, 0xc0, 0x00, 0x00, 0x00, 0x00, 0x00, 0x07, 0x07, 0x00, 0x00, 0x00, 0x00},
+	'%': {0x06, 0x09, 0x09, 0x06, 0xc0, 0xe0, 0x38, 0x1c, 0x07, 0x03, 0x20, 0x38, 0x1e, 0x07, 0x01, 0x00, 0x18, 0x24, 0x24, 0x18},
+	'°': {0x06, 0x0f, 0x09, 0x09, 0x0f, 0x06, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00},
+	' ': {0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00},
+}
@@ -0,0 +1,252 @@
+package main
+
+import (
+	"image"
+	"image/draw"
+	"image/gif"
+	"os"
+	"sync"
+	"time"
+)
+
+// default upper limit for GIFPlayer.MaxFPS
+const GIFMaxFPS = 10
+
+// delay of GIF frames that have none, as browsers show them
+const GIFDefaultDelay = 100 * time.Millisecond
+
+// GIFPlayer plays an animated GIF through LCDDisplay.
+//
+// The frames are converted to 1-bit once, when the player is created.
+// While a player is running it owns pcd8544_buffer, so don't draw or
+// call LCDDisplay from other goroutines until it has been stopped.
+type GIFPlayer struct {
+	// same meaning as gif.GIF.LoopCount: 0 loops forever, -1 plays
+	// the animation once, n plays it n+1 times
+	LoopCount int
+	// frames are never shown faster than this, 0 means no limit
+	MaxFPS int
+
+	pin    PCD8544_pin
+	frames [][6][LCDWIDTH]byte
+	delays []time.Duration
+
+	mu     sync.Mutex
+	stop   chan struct{}
+	done   chan struct{}
+	resume chan struct{} // non-nil while paused
+}
+
+// LoadGIFPlayer decodes a GIF file and returns a player for it
+func LoadGIFPlayer(pin PCD8544_pin, path string, mode FitMode) (*GIFPlayer, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	g, err := gif.DecodeAll(f)
+	if err != nil {
+		return nil, err
+	}
+	return NewGIFPlayer(pin, g, mode), nil
+}
+
+// NewGIFPlayer converts every frame of g to a full screen 1-bit frame,
+// applying the GIF disposal methods, and scales it to the screen with mode
+func NewGIFPlayer(pin PCD8544_pin, g *gif.GIF, mode FitMode) *GIFPlayer {
+	p := &GIFPlayer{
+		LoopCount: g.LoopCount,
+		MaxFPS:    GIFMaxFPS,
+		pin:       pin,
+	}
+
+	// logical screen of the GIF, every frame is drawn onto it
+	screen := image.Rect(0, 0, g.Config.Width, g.Config.Height)
+	if screen.Empty() {
+		for _, frame := range g.Image {
+			screen = screen.Union(frame.Bounds())
+		}
+	}
+	canvas := image.NewRGBA(screen)
+	previous := image.NewRGBA(screen)
+
+	for i, frame := range g.Image {
+		disposal := byte(gif.DisposalNone)
+		if i < len(g.Disposal) {
+			disposal = g.Disposal[i]
+		}
+		if disposal == gif.DisposalPrevious {
+			copy(previous.Pix, canvas.Pix)
+		}
+
+		draw.Draw(canvas, frame.Bounds(), frame, frame.Bounds().Min, draw.Over)
+
+		var buf [6][LCDWIDTH]byte
+		NewCanvas(&buf).DrawImage(canvas, 0, 0, int(LCDWIDTH), int(LCDHEIGHT), mode)
+		p.frames = append(p.frames, buf)
+
+		var delay time.Duration
+		if i < len(g.Delay) {
+			delay = time.Duration(g.Delay[i]) * 10 * time.Millisecond
+		}
+		p.delays = append(p.delays, delay)
+
+		// prepare the canvas for the next frame
+		switch disposal {
+		case gif.DisposalBackground:
+			draw.Draw(canvas, frame.Bounds(), image.Transparent, image.Point{}, draw.Src)
+		case gif.DisposalPrevious:
+			copy(canvas.Pix, previous.Pix)
+		}
+	}
+	return p
+}
+
+// Frames returns the number of frames in the animation
+func (p *GIFPlayer) Frames() int {
+	return len(p.frames)
+}
+
+// Play starts the animation in the background. It does nothing if the
+// player is already running or has no frames.
+func (p *GIFPlayer) Play() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if len(p.frames) == 0 {
+		return
+	}
+	if p.done != nil {
+		select {
+		case <-p.done:
+			// the last run has finished on its own
+		default:
+			return
+		}
+	}
+	p.stop = make(chan struct{})
+	p.done = make(chan struct{})
+	p.resume = nil
+	go p.run(p.stop, p.done)
+}
+
+// Pause holds the animation on the current frame
+func (p *GIFPlayer) Pause() {
+	p.mu.Lock()
+	if p.resume == nil {
+		p.resume = make(chan struct{})
+	}
+	p.mu.Unlock()
+}
+
+// Resume continues a paused animation
+func (p *GIFPlayer) Resume() {
+	p.mu.Lock()
+	if p.resume != nil {
+		close(p.resume)
+		p.resume = nil
+	}
+	p.mu.Unlock()
+}
+
+// Stop ends the animation and waits for the player to finish
+func (p *GIFPlayer) Stop() {
+	p.mu.Lock()
+	stop, done := p.stop, p.done
+	if stop != nil {
+		select {
+		case <-stop:
+		default:
+			close(stop)
+		}
+	}
+	p.mu.Unlock()
+
+	if done != nil {
+		<-done
+	}
+}
+
+// Wait blocks until the animation has played all its loops or was stopped
+func (p *GIFPlayer) Wait() {
+	p.mu.Lock()
+	done := p.done
+	p.mu.Unlock()
+
+	if done != nil {
+		<-done
+	}
+}
+
+func (p *GIFPlayer) run(stop, done chan struct{}) {
+	defer close(done)
+
+	loops := p.LoopCount + 1
+	if p.LoopCount < 0 {
+		loops = 1
+	}
+	for loop := 0; p.LoopCount == 0 || loop < loops; loop++ {
+		select {
+		case <-stop:
+			return
+		default:
+		}
+		for i := range p.frames {
+			start := time.Now()
+			if !p.waitPaused(stop) {
+				return
+			}
+
+			pcd8544_buffer = p.frames[i]
+			p.pin.LCDDisplay()
+
+			wait := p.frameDelay(i) - time.Since(start)
+			if wait > 0 {
+				select {
+				case <-stop:
+					return
+				case <-time.After(wait):
+				}
+			}
+		}
+	}
+}
+
+// waitPaused blocks while the player is paused, it returns false once stopped
+func (p *GIFPlayer) waitPaused(stop chan struct{}) bool {
+	p.mu.Lock()
+	resume := p.resume
+	p.mu.Unlock()
+
+	if resume == nil {
+		select {
+		case <-stop:
+			return false
+		default:
+			return true
+		}
+	}
+	select {
+	case <-stop:
+		return false
+	case <-resume:
+		return true
+	}
+}
+
+// frameDelay is the GIF delay of frame i, GIFDefaultDelay if it has none,
+// limited by MaxFPS
+func (p *GIFPlayer) frameDelay(i int) time.Duration {
+	delay := p.delays[i]
+	if delay <= 0 {
+		delay = GIFDefaultDelay
+	}
+	if p.MaxFPS > 0 {
+		min := time.Second / time.Duration(p.MaxFPS)
+		if delay < min {
+			delay = min
+		}
+	}
+	return delay
+}
@@ -0,0 +1,26 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFrameDelay(t *testing.T) {
+	p := &GIFPlayer{delays: []time.Duration{0, 20 * time.Millisecond, 500 * time.Millisecond}}
+	tests := []struct {
+		maxFPS int
+		want   []time.Duration
+	}{
+		{0, []time.Duration{GIFDefaultDelay, 20 * time.Millisecond, 500 * time.Millisecond}},
+		{10, []time.Duration{GIFDefaultDelay, 100 * time.Millisecond, 500 * time.Millisecond}},
+		{2, []time.Duration{500 * time.Millisecond, 500 * time.Millisecond, 500 * time.Millisecond}},
+	}
+	for _, tt := range tests {
+		p.MaxFPS = tt.maxFPS
+		for i, want := range tt.want {
+			if got := p.frameDelay(i); got != want {
+				t.Errorf("MaxFPS %d: frame %d waits %v, want %v", tt.maxFPS, i, got, want)
+			}
+		}
+	}
+}
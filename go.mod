@@ -1,10 +1,11 @@
 module github.com/sndnvaps/pcd8544
 
-go 1.15
+go 1.16
 
 require (
 	github.com/cheekybits/genny v1.0.0
 	github.com/stianeikeland/go-rpio v4.2.0+incompatible
 	github.com/stianeikeland/go-rpio/v4 v4.5.1
+	golang.org/x/image v0.0.0-20211028202545-6944b10bf410
 	golang.org/x/tools v0.1.7 // indirect
 )
@@ -0,0 +1,222 @@
+package main
+
+import (
+	"sync"
+	"time"
+
+	"github.com/sndnvaps/pcd8544/internal/frm"
+)
+
+// grey levels of a GreyCanvas pixel
+const (
+	GreyWhite uint8 = 0
+	GreyLight uint8 = 1
+	GreyDark  uint8 = 2
+	GreyBlack uint8 = 3
+)
+
+// FRMPattern describes the frame-rate modulation cycle of a GreyCanvas
+type FRMPattern struct {
+	// number of frames in one cycle, 1 to 32, other values are clamped
+	Frames int
+	// bit f of Levels[l] is set when a pixel of grey level l is on in frame f
+	Levels [4]uint32
+	// shift the cycle by (x+y) for every pixel, so neighbouring pixels of
+	// the same level don't blink together, which reduces visible flicker
+	Spread bool
+}
+
+// DefaultFRMPattern shows light grey in 1 of 3 frames and dark grey in 2 of 3
+var DefaultFRMPattern = FRMPattern{
+	Frames: 3,
+	Levels: [4]uint32{0x0, 0x1, 0x3, 0x7},
+	Spread: true,
+}
+
+// default refresh rate of GreyCanvas.Start, in frames per second
+const GreyRefreshRate = 60
+
+// GreyCanvas emulates 4 grey levels on the PCD8544. It keeps two bit-planes
+// in the page layout of pcd8544_buffer (2 bits per pixel) and a refresh
+// goroutine shows one 1-bit frame of the FRM cycle after the other.
+type GreyCanvas struct {
+	// set it before Start, or with SetPattern while the canvas is shown
+	Pattern FRMPattern
+
+	mu     sync.Mutex
+	planes [2][6][LCDWIDTH]byte // planes[0] is the low bit, planes[1] the high bit
+	stop   chan struct{}
+	done   chan struct{}
+}
+
+func NewGreyCanvas() *GreyCanvas {
+	return &GreyCanvas{Pattern: DefaultFRMPattern}
+}
+
+// SetPattern changes the FRM pattern, also while the refresh goroutine runs
+func (g *GreyCanvas) SetPattern(pat FRMPattern) {
+	g.mu.Lock()
+	g.Pattern = pat
+	g.mu.Unlock()
+}
+
+// SetPixel sets the grey level (0-3) of a pixel, points off the screen are ignored
+func (g *GreyCanvas) SetPixel(x, y int, level uint8) {
+	if x < 0 || y < 0 || x >= int(LCDWIDTH) || y >= int(LCDHEIGHT) {
+		return
+	}
+	g.mu.Lock()
+	g.setPixel(x, y, level)
+	g.mu.Unlock()
+}
+
+func (g *GreyCanvas) setPixel(x, y int, level uint8) {
+	bit := byte(1) << uint(y&7)
+	for i := range g.planes {
+		if level&(1<<uint(i)) != 0 {
+			g.planes[i][y>>3][x] |= bit
+		} else {
+			g.planes[i][y>>3][x] &^= bit
+		}
+	}
+}
+
+// Pixel returns the grey level of a pixel
+func (g *GreyCanvas) Pixel(x, y int) uint8 {
+	if x < 0 || y < 0 || x >= int(LCDWIDTH) || y >= int(LCDHEIGHT) {
+		return GreyWhite
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.pixel(x, y)
+}
+
+func (g *GreyCanvas) pixel(x, y int) uint8 {
+	var level uint8
+	for i := range g.planes {
+		if g.planes[i][y>>3][x]&(1<<uint(y&7)) != 0 {
+			level |= 1 << uint(i)
+		}
+	}
+	return level
+}
+
+// FillRect sets every pixel of the w*h box at (x, y) to level
+func (g *GreyCanvas) FillRect(x, y, w, h int, level uint8) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	for py := y; py < y+h; py++ {
+		for px := x; px < x+w; px++ {
+			if px >= 0 && py >= 0 && px < int(LCDWIDTH) && py < int(LCDHEIGHT) {
+				g.setPixel(px, py, level)
+			}
+		}
+	}
+}
+
+// Clear sets every pixel to white
+func (g *GreyCanvas) Clear() {
+	g.mu.Lock()
+	g.planes = [2][6][LCDWIDTH]byte{}
+	g.mu.Unlock()
+}
+
+// Frame returns the 1-bit frame n of the FRM cycle, as it is sent to the display
+func (g *GreyCanvas) Frame(n int) (frame [6][LCDWIDTH]byte) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	pat := g.Pattern
+	pat.Frames = frm.Frames(pat.Frames)
+	n = frm.Phase(n, 0, 0, pat.Frames, false)
+
+	if !pat.Spread {
+		// every pixel uses the same frame, so whole page bytes can be combined
+		for p := 0; p < 6; p++ {
+			for x := 0; x < int(LCDWIDTH); x++ {
+				lo, hi := g.planes[0][p][x], g.planes[1][p][x]
+				masks := [4]byte{^hi & ^lo, ^hi & lo, hi & ^lo, hi & lo}
+				for l, m := range masks {
+					if frm.On(pat.Levels[l], n) {
+						frame[p][x] |= m
+					}
+				}
+			}
+		}
+		return frame
+	}
+
+	for y := 0; y < int(LCDHEIGHT); y++ {
+		for x := 0; x < int(LCDWIDTH); x++ {
+			if frm.On(pat.Levels[g.pixel(x, y)], frm.Phase(n, x, y, pat.Frames, true)) {
+				frame[y>>3][x] |= 1 << uint(y&7)
+			}
+		}
+	}
+	return frame
+}
+
+// Frames returns one complete FRM cycle, the sequence of frames Start shows
+func (g *GreyCanvas) Frames() [][6][LCDWIDTH]byte {
+	g.mu.Lock()
+	count := frm.Frames(g.Pattern.Frames)
+	g.mu.Unlock()
+
+	frames := make([][6][LCDWIDTH]byte, count)
+	for i := range frames {
+		frames[i] = g.Frame(i)
+	}
+	return frames
+}
+
+// Start shows the canvas in the background, switching to the next frame of
+// the cycle rate times per second (GreyRefreshRate if rate <= 0). Like the
+// GIFPlayer it owns pcd8544_buffer until Stop is called.
+func (g *GreyCanvas) Start(pin PCD8544_pin, rate int) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.stop != nil {
+		return
+	}
+	if rate <= 0 {
+		rate = GreyRefreshRate
+	}
+	g.stop = make(chan struct{})
+	g.done = make(chan struct{})
+	go g.refresh(pin, time.Second/time.Duration(rate), g.stop, g.done)
+}
+
+// Stop ends the refresh goroutine and waits for it to finish
+func (g *GreyCanvas) Stop() {
+	g.mu.Lock()
+	stop, done := g.stop, g.done
+	g.stop, g.done = nil, nil
+	g.mu.Unlock()
+
+	if stop != nil {
+		close(stop)
+		<-done
+	}
+}
+
+func (g *GreyCanvas) refresh(pin PCD8544_pin, interval time.Duration, stop, done chan struct{}) {
+	defer close(done)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for n := 0; ; {
+		pcd8544_buffer = g.Frame(n)
+		pin.LCDDisplay()
+
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+
+		g.mu.Lock()
+		n = (n + 1) % frm.Frames(g.Pattern.Frames)
+		g.mu.Unlock()
+	}
+}
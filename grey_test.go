@@ -0,0 +1,37 @@
+package main
+
+import "testing"
+
+func TestGreyFrames(t *testing.T) {
+	g := NewGreyCanvas()
+	g.FillRect(0, 0, 84, 48, GreyLight)
+	g.SetPixel(0, 0, GreyBlack)
+
+	for _, tt := range []struct{ frames, want int }{{3, 3}, {0, 1}, {40, 32}} {
+		pat := DefaultFRMPattern
+		pat.Frames = tt.frames
+		pat.Levels[GreyBlack] = 0xffffffff
+		g.SetPattern(pat)
+		frames := g.Frames()
+		if len(frames) != tt.want {
+			t.Errorf("Frames %d: cycle of %d frames, want %d", tt.frames, len(frames), tt.want)
+		}
+		for n, f := range frames {
+			if f[0][0]&1 == 0 {
+				t.Errorf("Frames %d: black pixel off in frame %d", tt.frames, n)
+			}
+		}
+	}
+
+	// a light pixel is on in one of the three frames of the default pattern
+	g.SetPattern(DefaultFRMPattern)
+	on := 0
+	for _, f := range g.Frames() {
+		if f[1][5]&(1<<2) != 0 { // pixel (5, 10)
+			on++
+		}
+	}
+	if on != 1 {
+		t.Errorf("light pixel on in %d frames, want 1", on)
+	}
+}
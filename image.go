@@ -0,0 +1,168 @@
+package main
+
+import (
+	"image"
+	"image/color"
+	"os"
+
+	// decoders for the formats accepted by LCDDrawImageFile
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+
+	_ "golang.org/x/image/bmp"
+)
+
+// FitMode selects how an image is mapped into the target box
+type FitMode int
+
+const (
+	ImageFit     FitMode = iota // scale to fit inside the box, keep aspect ratio
+	ImageFill                   // scale to cover the box, keep aspect ratio, crop the rest
+	ImageStretch                // scale to exactly the box size
+	ImageCenter                 // no scaling, center the image and crop the rest
+)
+
+// pixels darker than ImageThreshold (0-255) are drawn black
+var ImageThreshold uint8 = 128
+
+// LoadImage decodes a PNG, GIF, JPEG or BMP file
+func LoadImage(path string) (image.Image, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	img, _, err := image.Decode(f)
+	if err != nil {
+		return nil, err
+	}
+	return img, nil
+}
+
+// LCDDrawImageFile loads an image file and draws it with LCDDrawImage
+func LCDDrawImageFile(path string, x, y, w, h int, mode FitMode) error {
+	img, err := LoadImage(path)
+	if err != nil {
+		return err
+	}
+	LCDDrawImage(img, x, y, w, h, mode)
+	return nil
+}
+
+// LCDDrawImage draws img on Screen, see Canvas.DrawImage
+func LCDDrawImage(img image.Image, x, y, w, h int, mode FitMode) {
+	Screen.DrawImage(img, x, y, w, h, mode)
+}
+
+// DrawImage converts img to 1-bit and draws it into the box at (x, y)
+// with size w*h. Dark pixels are foreground and light pixels background
+// (see SetMode and SetTransparent), transparent pixels of img leave the
+// canvas untouched.
+func (c *Canvas) DrawImage(img image.Image, x, y, w, h int, mode FitMode) {
+	if img == nil || w <= 0 || h <= 0 {
+		return
+	}
+	src := img.Bounds()
+	if src.Empty() {
+		return
+	}
+
+	box := image.Rect(x, y, x+w, y+h)
+	dst := fitRect(src.Dx(), src.Dy(), box, mode)
+
+	// only the part of the scaled image inside the box and the clip is visible
+	vis := dst.Intersect(box).Intersect(c.Clip())
+	for dy := vis.Min.Y; dy < vis.Max.Y; dy++ {
+		for dx := vis.Min.X; dx < vis.Max.X; dx++ {
+			black, opaque := sampleImage(img, src, dst, dx, dy)
+			if opaque {
+				bit := byte(1) << uint(dy&7)
+				if black {
+					c.writeByte(dx, dy>>3, bit, bit)
+				} else {
+					c.writeByte(dx, dy>>3, 0, bit)
+				}
+			}
+		}
+	}
+}
+
+// fitRect returns where an sw*sh image lands for the given box and mode
+func fitRect(sw, sh int, box image.Rectangle, mode FitMode) image.Rectangle {
+	bw, bh := box.Dx(), box.Dy()
+	w, h := sw, sh
+
+	switch mode {
+	case ImageStretch:
+		return box
+	case ImageFit:
+		// compare sw/sh against bw/bh without floating point
+		if sw*bh > sh*bw {
+			w, h = bw, (sh*bw+sw/2)/sw
+		} else {
+			w, h = (sw*bh+sh/2)/sh, bh
+		}
+	case ImageFill:
+		if sw*bh > sh*bw {
+			w, h = (sw*bh+sh/2)/sh, bh
+		} else {
+			w, h = bw, (sh*bw+sw/2)/sw
+		}
+	}
+	if w < 1 {
+		w = 1
+	}
+	if h < 1 {
+		h = 1
+	}
+
+	x := box.Min.X + (bw-w)/2
+	y := box.Min.Y + (bh-h)/2
+	return image.Rect(x, y, x+w, y+h)
+}
+
+// sampleImage averages the source pixels that map onto the destination
+// pixel (dx, dy) and reports whether the result is black and opaque
+func sampleImage(img image.Image, src, dst image.Rectangle, dx, dy int) (black bool, opaque bool) {
+	sx0 := src.Min.X + (dx-dst.Min.X)*src.Dx()/dst.Dx()
+	sx1 := src.Min.X + (dx-dst.Min.X+1)*src.Dx()/dst.Dx()
+	sy0 := src.Min.Y + (dy-dst.Min.Y)*src.Dy()/dst.Dy()
+	sy1 := src.Min.Y + (dy-dst.Min.Y+1)*src.Dy()/dst.Dy()
+	if sx1 <= sx0 {
+		sx1 = sx0 + 1
+	}
+	if sy1 <= sy0 {
+		sy1 = sy0 + 1
+	}
+
+	var luma, alpha, n uint32
+	for sy := sy0; sy < sy1; sy++ {
+		for sx := sx0; sx < sx1; sx++ {
+			l, a := lumaAlpha(img.At(sx, sy))
+			luma += l
+			alpha += a
+			n++
+		}
+	}
+	luma /= n
+	alpha /= n
+
+	if alpha < 0x8000 {
+		return false, false
+	}
+	return luma < uint32(ImageThreshold)<<8, true
+}
+
+// lumaAlpha returns the 16-bit luminance of c composited on white, and its alpha
+func lumaAlpha(c color.Color) (uint32, uint32) {
+	r, g, b, a := c.RGBA()
+	// premultiplied colour over a white background
+	r += 0xffff - a
+	g += 0xffff - a
+	b += 0xffff - a
+	// same weights as color.GrayModel
+	y := (19595*r + 38470*g + 7471*b + 1<<15) >> 16
+	return y, a
+}
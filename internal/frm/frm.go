@@ -0,0 +1,37 @@
+// Package frm holds the frame-rate modulation arithmetic of GreyCanvas,
+// apart from the display so it can be tested on any machine.
+package frm
+
+// MaxFrames is the longest cycle, one bit of a uint32 level mask per frame
+const MaxFrames = 32
+
+// Frames clamps the cycle length n to 1..MaxFrames
+func Frames(n int) int {
+	if n < 1 {
+		return 1
+	}
+	if n > MaxFrames {
+		return MaxFrames
+	}
+	return n
+}
+
+// Phase returns the frame of a cycle of length frames that pixel (x, y)
+// shows in frame n. With spread the cycle is shifted by x+y, so
+// neighbouring pixels of the same level don't blink together.
+func Phase(n, x, y, frames int, spread bool) int {
+	frames = Frames(frames)
+	if spread {
+		n += x + y
+	}
+	n %= frames
+	if n < 0 {
+		n += frames
+	}
+	return n
+}
+
+// On reports whether a level with the given mask is on in frame f
+func On(mask uint32, f int) bool {
+	return mask&(1<<uint(f)) != 0
+}
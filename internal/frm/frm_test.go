@@ -0,0 +1,78 @@
+package frm
+
+import "testing"
+
+// the default pattern of GreyCanvas
+var (
+	levels = [4]uint32{0x0, 0x1, 0x3, 0x7}
+	frames = 3
+)
+
+// onCount counts the frames of one cycle in which pixel (x, y) is on
+func onCount(mask uint32, x, y int, spread bool) int {
+	count := 0
+	for n := 0; n < frames; n++ {
+		if On(mask, Phase(n, x, y, frames, spread)) {
+			count++
+		}
+	}
+	return count
+}
+
+func TestOnCount(t *testing.T) {
+	for _, spread := range []bool{false, true} {
+		for l, mask := range levels {
+			for y := 0; y < 48; y++ {
+				for x := 0; x < 84; x++ {
+					if n := onCount(mask, x, y, spread); n != l {
+						t.Fatalf("spread %v: level %d at %d,%d is on in %d of %d frames, want %d",
+							spread, l, x, y, n, frames, l)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestSpread(t *testing.T) {
+	light := levels[1]
+	for n := 0; n < frames; n++ {
+		on := 0
+		for y := 0; y < 48; y++ {
+			for x := 0; x < 84; x++ {
+				if On(light, Phase(n, x, y, frames, true)) {
+					on++
+					// with spread no two neighbours of the same level are on together
+					if On(light, Phase(n, x+1, y, frames, true)) || On(light, Phase(n, x, y+1, frames, true)) {
+						t.Fatalf("frame %d: %d,%d is on together with a neighbour", n, x, y)
+					}
+				}
+				// without it the whole level blinks as one
+				if On(light, Phase(n, x, y, frames, false)) != On(light, Phase(n, 0, 0, frames, false)) {
+					t.Fatalf("frame %d: %d,%d out of step without spread", n, x, y)
+				}
+			}
+		}
+		// and the light pixels are spread evenly over the frames
+		if on != 84*48/frames {
+			t.Errorf("frame %d: %d light pixels on, want %d", n, on, 84*48/frames)
+		}
+	}
+}
+
+func TestFrames(t *testing.T) {
+	for _, c := range []struct{ in, want int }{{-1, 1}, {0, 1}, {1, 1}, {32, 32}, {33, 32}, {100, 32}} {
+		if got := Frames(c.in); got != c.want {
+			t.Errorf("Frames(%d) = %d, want %d", c.in, got, c.want)
+		}
+	}
+	// 1<<32 would never be on, a clamped cycle stays inside the mask
+	for n := 0; n < 100; n++ {
+		if f := Phase(n, 5, 7, 40, true); f < 0 || f >= MaxFrames {
+			t.Fatalf("Phase(%d) = %d", n, f)
+		}
+	}
+	if f := Phase(-1, 0, 0, 3, false); f != 2 {
+		t.Errorf("Phase(-1) = %d, want 2", f)
+	}
+}
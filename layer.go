@@ -0,0 +1,62 @@
+package main
+
+// Layer is a 1-bit drawing plane with a mask. Only the pixels set in the
+// mask cover the layers below it, everything else is see-through. Drawing
+// on the embedded Canvas marks the drawn pixels (foreground and background)
+// in the mask, so a popup drawn with a cleared background hides what is
+// under it. Layers are combined into the screen by Layers.Composite.
+type Layer struct {
+	*Canvas
+	// a hidden layer is left out when compositing
+	Hidden bool
+
+	pixels, mask [6][LCDWIDTH]byte
+}
+
+// NewLayer returns an empty, fully transparent layer
+func NewLayer() *Layer {
+	l := &Layer{}
+	l.Canvas = NewCanvas(&l.pixels)
+	l.Canvas.cover = &l.mask
+	return l
+}
+
+// Mask returns a canvas drawing into the mask of the layer. Pixels set in
+// the mask are opaque, draw with DrawClear to punch holes into the layer.
+func (l *Layer) Mask() *Canvas {
+	return NewCanvas(&l.mask)
+}
+
+// Erase makes the whole layer empty and transparent again
+func (l *Layer) Erase() {
+	l.pixels = [6][LCDWIDTH]byte{}
+	l.mask = [6][LCDWIDTH]byte{}
+}
+
+// Layers is a stack of layers, the first one is at the bottom. A typical
+// screen has a background layer with static chrome, a content layer and an
+// overlay layer for popups: closing a popup is just erasing or hiding the
+// overlay, the content below it comes back unchanged.
+type Layers []*Layer
+
+// Composite stacks the visible layers onto a clear (white) dst
+func (ls Layers) Composite(dst *[6][LCDWIDTH]byte) {
+	*dst = [6][LCDWIDTH]byte{}
+	for _, l := range ls {
+		if l == nil || l.Hidden {
+			continue
+		}
+		for p := range dst {
+			for x := range dst[p] {
+				m := l.mask[p][x]
+				dst[p][x] = dst[p][x]&^m | l.pixels[p][x]&m
+			}
+		}
+	}
+}
+
+// LCDDisplayLayers composites ls into pcd8544_buffer and sends it to the LCD
+func (pin PCD8544_pin) LCDDisplayLayers(ls Layers) {
+	ls.Composite(&pcd8544_buffer)
+	pin.LCDDisplay()
+}
@@ -0,0 +1,248 @@
+package main
+
+import (
+	"image"
+	"math"
+)
+
+// LineCap is the shape of the ends of a wide line or dash
+type LineCap int
+
+const (
+	CapButt   LineCap = iota // the line ends at its end point
+	CapSquare                // the line extends by half its width
+	CapRound                 // the line ends in a half circle
+)
+
+// LineStyle controls how DrawLine, DrawRect, DrawRoundRect, DrawPolygon,
+// DrawTriangle and StrokePath draw their outlines
+type LineStyle struct {
+	// line width in pixels, 0 and 1 both mean a 1 pixel line. Even widths
+	// can't be centered on the line, they lean half a pixel to the right of
+	// the direction of travel, which is the inside of rectangles and of
+	// polygons given clockwise.
+	Width int
+	// alternating on and off lengths in pixels, starting with on; nil is solid
+	Dash []int
+	Cap  LineCap
+}
+
+var (
+	SolidLine  = LineStyle{Width: 1}
+	DottedLine = LineStyle{Width: 1, Dash: []int{1, 1}}
+	DashedLine = LineStyle{Width: 1, Dash: []int{4, 2}}
+)
+
+// plain reports whether the style is a solid 1 pixel line
+func (s LineStyle) plain() bool {
+	return s.Width <= 1 && !s.dashed()
+}
+
+func (s LineStyle) dashed() bool {
+	total := 0
+	for _, d := range s.Dash {
+		total += d
+	}
+	return len(s.Dash) > 0 && total > 0
+}
+
+// on reports whether the pixel pos along the line is inside a dash
+func (s LineStyle) on(pos int) bool {
+	if !s.dashed() {
+		return true
+	}
+	total := 0
+	for _, d := range s.Dash {
+		if d > 0 {
+			total += d
+		}
+	}
+	// odd patterns repeat twice, so on and off swap in the second round
+	if len(s.Dash)%2 == 1 {
+		total *= 2
+	}
+	pos %= total
+	for i := 0; ; i++ {
+		d := s.Dash[i%len(s.Dash)]
+		if d < 0 {
+			d = 0
+		}
+		if pos < d {
+			return i%2 == 0
+		}
+		pos -= d
+	}
+}
+
+// LineStyle returns the current line style
+func (c *Canvas) LineStyle() LineStyle {
+	return c.style
+}
+
+// SetLineStyle sets the width, dash pattern and caps of the following outlines
+func (c *Canvas) SetLineStyle(style LineStyle) {
+	c.style = style
+}
+
+// strokePolylines draws connected line segments with the line style. The
+// dash pattern runs on along each polyline and every pixel is drawn once,
+// so DrawInvert works where segments meet.
+func (c *Canvas) strokePolylines(lines [][]image.Point) {
+	var mask [6][LCDWIDTH]byte
+	m := c.maskCanvas(&mask)
+
+	st := c.style
+	for _, pts := range lines {
+		pos := 0
+		for i := 1; i < len(pts); i++ {
+			a, b := pts[i-1], pts[i]
+			n := abs(b.X - a.X)
+			if d := abs(b.Y - a.Y); d > n {
+				n = d
+			}
+			// the end point belongs to the next segment, except for the last one
+			last := n
+			if i < len(pts)-1 {
+				last--
+			}
+			strokeSegment(m, st, a, b, n, pos, last)
+			pos += n
+
+			if st.Width > 1 && i < len(pts)-1 && st.on(pos) {
+				// round joins close the gaps between wide segments, one dot
+				// on the (biased) center line of either segment
+				for _, seg := range [][2]image.Point{{a, b}, {b, pts[i+1]}} {
+					ux, uy := unit(seg[0], seg[1])
+					bx, by := strokeBias(ux, uy, st.Width)
+					wideDot(m, float64(b.X)+bx, float64(b.Y)+by, st.Width, true)
+				}
+			}
+		}
+	}
+	c.blend(&mask)
+}
+
+// strokeSegment draws the pixels 0 <= k <= last of the n step line from a
+// to b that fall into a dash, pos is the dash position of pixel 0
+func strokeSegment(m *Canvas, st LineStyle, a, b image.Point, n, pos, last int) {
+	at := func(k int) image.Point {
+		if n == 0 {
+			return a
+		}
+		return image.Pt(a.X+divRound((b.X-a.X)*k, n), a.Y+divRound((b.Y-a.Y)*k, n))
+	}
+	ux, uy := unit(a, b)
+
+	for k := 0; k <= last; {
+		if !st.on(pos + k) {
+			k++
+			continue
+		}
+		end := k
+		for end < last && st.on(pos+end+1) {
+			end++
+		}
+		p0, p1 := at(k), at(end)
+		if st.Width <= 1 {
+			m.drawLine(p0.X, p0.Y, p1.X, p1.Y)
+		} else {
+			wideSegment(m, p0, p1, ux, uy, st.Width, st.Cap)
+		}
+		k = end + 1
+	}
+}
+
+// unit returns the direction from a to b as a unit vector, 0, 0 if a == b
+func unit(a, b image.Point) (float64, float64) {
+	dx, dy := float64(b.X-a.X), float64(b.Y-a.Y)
+	l := math.Hypot(dx, dy)
+	if l == 0 {
+		return 0, 0
+	}
+	return dx / l, dy / l
+}
+
+// strokeBias returns how far the center line of a wide stroke in direction
+// (ux, uy) is moved off the points it was drawn through. Odd widths are
+// centered. Even widths can't be, they move half a pixel to the right of
+// the direction of travel (as seen on the screen, y down), which keeps the
+// outlines of rectangles, round rectangles and clockwise polygons inside
+// their shape. A stroke without a direction moves down and to the right.
+func strokeBias(ux, uy float64, width int) (float64, float64) {
+	if width%2 == 1 {
+		return 0, 0
+	}
+	if ux == 0 && uy == 0 {
+		return 0.5, 0.5
+	}
+	return -uy / 2, ux / 2
+}
+
+// wideSegment fills a line of the given width from a to b, going in
+// direction (ux, uy). A pixel is part of the line when its center is less
+// than width/2 away from the center line (see strokeBias). Butt caps end
+// half a pixel beyond a and b, so the end points are drawn, square caps
+// another width/2 further.
+func wideSegment(m *Canvas, a, b image.Point, ux, uy float64, width int, cap LineCap) {
+	bx, by := strokeBias(ux, uy, width)
+	if ux == 0 && uy == 0 {
+		wideDot(m, float64(a.X)+bx, float64(a.Y)+by, width, cap == CapRound)
+		return
+	}
+	half := float64(width) / 2
+	ax, ay := float64(a.X)+bx, float64(a.Y)+by
+
+	// range of the pixel positions along the line
+	l := float64(b.X-a.X)*ux + float64(b.Y-a.Y)*uy
+	t0, t1 := -0.5, l+0.5
+	switch cap {
+	case CapSquare:
+		t0, t1 = t0-half, t1+half
+	case CapRound:
+		t0, t1 = 0, l
+	}
+
+	r := image.Rect(a.X, a.Y, b.X, b.Y).Canon().Inset(-width - 1)
+	r = r.Intersect(m.Clip())
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		for x := r.Min.X; x < r.Max.X; x++ {
+			px, py := float64(x)-ax, float64(y)-ay
+			t := px*ux + py*uy
+			d := px*-uy + py*ux
+			if t > t0 && t < t1 && math.Abs(d) < half {
+				m.plot(x, y)
+			}
+		}
+	}
+
+	if cap == CapRound {
+		wideDot(m, ax, ay, width, true)
+		wideDot(m, float64(b.X)+bx, float64(b.Y)+by, width, true)
+	}
+}
+
+// wideDot fills the pixels whose centers are less than width/2 away from
+// (cx, cy), in a circle if round, else in a square
+func wideDot(m *Canvas, cx, cy float64, width int, round bool) {
+	half := float64(width) / 2
+	r := image.Rect(int(math.Floor(cx-half)), int(math.Floor(cy-half)),
+		int(math.Ceil(cx+half))+1, int(math.Ceil(cy+half))+1)
+	r = r.Intersect(m.Clip())
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		for x := r.Min.X; x < r.Max.X; x++ {
+			dx, dy := float64(x)-cx, float64(y)-cy
+			if round && math.Hypot(dx, dy) < half ||
+				!round && math.Abs(dx) < half && math.Abs(dy) < half {
+				m.plot(x, y)
+			}
+		}
+	}
+}
+
+// divRound divides rounding to the nearest integer
+func divRound(a, b int) int {
+	if (a < 0) != (b < 0) {
+		return (a - b/2) / b
+	}
+	return (a + b/2) / b
+}
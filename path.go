@@ -0,0 +1,239 @@
+package main
+
+import (
+	"image"
+	"math"
+)
+
+// how far (in pixels) a flattened curve may deviate from the real one
+const pathTolerance = 0.25
+
+// Path is a canvas-style vector path made of lines and curves. Curves are
+// flattened into line segments, which StrokePath and FillPath draw with
+// the normal line and polygon routines. The zero value is an empty path.
+type Path struct {
+	subpaths []subpath
+}
+
+type subpath struct {
+	pts    []vec
+	closed bool
+}
+
+type vec struct {
+	x, y float64
+}
+
+func (a vec) add(b vec) vec             { return vec{a.x + b.x, a.y + b.y} }
+func (a vec) sub(b vec) vec             { return vec{a.x - b.x, a.y - b.y} }
+func (a vec) mul(k float64) vec         { return vec{a.x * k, a.y * k} }
+func (a vec) dot(b vec) float64         { return a.x*b.x + a.y*b.y }
+func (a vec) length() float64           { return math.Hypot(a.x, a.y) }
+func (a vec) point() image.Point        { return image.Pt(int(math.Round(a.x)), int(math.Round(a.y))) }
+func (a vec) lerp(b vec, t float64) vec { return a.add(b.sub(a).mul(t)) }
+
+func NewPath() *Path {
+	return &Path{}
+}
+
+// current returns the sub-path being built, starting one at the current
+// point if the last one was closed
+func (p *Path) current() *subpath {
+	n := len(p.subpaths)
+	if n == 0 {
+		return nil
+	}
+	last := &p.subpaths[n-1]
+	if last.closed {
+		p.subpaths = append(p.subpaths, subpath{pts: []vec{last.pts[0]}})
+		return &p.subpaths[n]
+	}
+	return last
+}
+
+// point returns the current point, ok is false for an empty path
+func (p *Path) point() (v vec, ok bool) {
+	sp := p.current()
+	if sp == nil {
+		return vec{}, false
+	}
+	return sp.pts[len(sp.pts)-1], true
+}
+
+// MoveTo starts a new sub-path at (x, y)
+func (p *Path) MoveTo(x, y float64) {
+	p.subpaths = append(p.subpaths, subpath{pts: []vec{{x, y}}})
+}
+
+// LineTo adds a straight line to (x, y)
+func (p *Path) LineTo(x, y float64) {
+	sp := p.current()
+	if sp == nil {
+		p.MoveTo(x, y)
+		return
+	}
+	sp.pts = append(sp.pts, vec{x, y})
+}
+
+// QuadTo adds a quadratic Bézier curve with control point (cx, cy) ending at (x, y)
+func (p *Path) QuadTo(cx, cy, x, y float64) {
+	p0, ok := p.point()
+	if !ok {
+		p.MoveTo(cx, cy)
+		p0 = vec{cx, cy}
+	}
+	p1, p2 := vec{cx, cy}, vec{x, y}
+
+	// the chord error of a step h is |p0-2p1+p2|*h*h/4
+	dd := p0.sub(p1.mul(2)).add(p2).length()
+	n := segments(math.Sqrt(dd / (4 * pathTolerance)))
+	for i := 1; i <= n; i++ {
+		t := float64(i) / float64(n)
+		a, b := p0.lerp(p1, t), p1.lerp(p2, t)
+		v := a.lerp(b, t)
+		p.LineTo(v.x, v.y)
+	}
+}
+
+// CubicTo adds a cubic Bézier curve with control points (c1x, c1y) and
+// (c2x, c2y) ending at (x, y)
+func (p *Path) CubicTo(c1x, c1y, c2x, c2y, x, y float64) {
+	p0, ok := p.point()
+	if !ok {
+		p.MoveTo(c1x, c1y)
+		p0 = vec{c1x, c1y}
+	}
+	p1, p2, p3 := vec{c1x, c1y}, vec{c2x, c2y}, vec{x, y}
+
+	dd := math.Max(p0.sub(p1.mul(2)).add(p2).length(), p1.sub(p2.mul(2)).add(p3).length())
+	n := segments(math.Sqrt(3 * dd / (4 * pathTolerance)))
+	for i := 1; i <= n; i++ {
+		t := float64(i) / float64(n)
+		a, b, c := p0.lerp(p1, t), p1.lerp(p2, t), p2.lerp(p3, t)
+		d, e := a.lerp(b, t), b.lerp(c, t)
+		v := d.lerp(e, t)
+		p.LineTo(v.x, v.y)
+	}
+}
+
+// ArcTo works like the HTML canvas arcTo: it adds a line from the current
+// point towards (x1, y1) and an arc of radius r that is tangent to the
+// lines current point -> (x1, y1) and (x1, y1) -> (x2, y2)
+func (p *Path) ArcTo(x1, y1, x2, y2, r float64) {
+	p0, ok := p.point()
+	if !ok {
+		p.MoveTo(x1, y1)
+		return
+	}
+	corner, end := vec{x1, y1}, vec{x2, y2}
+
+	v1, v2 := p0.sub(corner), end.sub(corner)
+	l1, l2 := v1.length(), v2.length()
+	if r <= 0 || l1 == 0 || l2 == 0 {
+		p.LineTo(x1, y1)
+		return
+	}
+	v1, v2 = v1.mul(1/l1), v2.mul(1/l2)
+
+	// half of the angle between both lines, 0 or pi when they are collinear
+	half := math.Acos(math.Max(-1, math.Min(1, v1.dot(v2)))) / 2
+	if math.Sin(half) < 1e-9 || math.Tan(half) < 1e-9 {
+		p.LineTo(x1, y1)
+		return
+	}
+
+	t1 := corner.add(v1.mul(r / math.Tan(half)))
+	t2 := corner.add(v2.mul(r / math.Tan(half)))
+	bis := v1.add(v2)
+	center := corner.add(bis.mul(r / math.Sin(half) / bis.length()))
+
+	p.LineTo(t1.x, t1.y)
+	a1 := math.Atan2(t1.y-center.y, t1.x-center.x)
+	a2 := math.Atan2(t2.y-center.y, t2.x-center.x)
+	// the tangent arc is always the short one
+	sweep := a2 - a1
+	if sweep > math.Pi {
+		sweep -= 2 * math.Pi
+	} else if sweep < -math.Pi {
+		sweep += 2 * math.Pi
+	}
+	p.arc(center, r, a1, sweep)
+}
+
+// Arc adds an arc of radius r around (cx, cy) from angle start to end, with
+// a line from the current point to its start. Angles are in degrees like
+// DrawArc: 0 points to the right and angles grow clockwise.
+func (p *Path) Arc(cx, cy, r, start, end float64) {
+	a1 := start * math.Pi / 180
+	sweep := (end - start) * math.Pi / 180
+	first := vec{cx + r*math.Cos(a1), cy + r*math.Sin(a1)}
+	if _, ok := p.point(); ok {
+		p.LineTo(first.x, first.y)
+	} else {
+		p.MoveTo(first.x, first.y)
+	}
+	p.arc(vec{cx, cy}, r, a1, sweep)
+}
+
+// arc flattens an arc from angle a1 (radians) over sweep, the current point
+// is expected at its start
+func (p *Path) arc(center vec, r, a1, sweep float64) {
+	// the chord error of a step h is r*(1-cos(h/2))
+	step := math.Pi / 2
+	if r > pathTolerance {
+		step = 2 * math.Acos(1-pathTolerance/r)
+	}
+	n := segments(math.Abs(sweep) / step)
+	for i := 1; i <= n; i++ {
+		a := a1 + sweep*float64(i)/float64(n)
+		p.LineTo(center.x+r*math.Cos(a), center.y+r*math.Sin(a))
+	}
+}
+
+// Close closes the current sub-path with a line back to its start
+func (p *Path) Close() {
+	if n := len(p.subpaths); n > 0 {
+		p.subpaths[n-1].closed = true
+	}
+}
+
+// segments rounds a segment count up, keeping it within sane limits
+func segments(n float64) int {
+	if math.IsNaN(n) || n < 1 {
+		return 1
+	}
+	if n > 256 {
+		return 256
+	}
+	return int(math.Ceil(n))
+}
+
+// polylines returns the flattened sub-paths in pixel coordinates, closed
+// sub-paths end with their first point again
+func (p *Path) polylines() [][]image.Point {
+	var lines [][]image.Point
+	for _, sp := range p.subpaths {
+		var pts []image.Point
+		for _, v := range sp.pts {
+			pt := v.point()
+			if len(pts) == 0 || pts[len(pts)-1] != pt {
+				pts = append(pts, pt)
+			}
+		}
+		if sp.closed && len(pts) > 1 && pts[0] != pts[len(pts)-1] {
+			pts = append(pts, pts[0])
+		}
+		lines = append(lines, pts)
+	}
+	return lines
+}
+
+// StrokePath draws the outline of the path using the line style
+func (c *Canvas) StrokePath(p *Path) {
+	c.strokePolylines(p.polylines())
+}
+
+// FillPath fills the path with rule, open sub-paths are closed implicitly
+func (c *Canvas) FillPath(p *Path, rule FillRule) {
+	c.fillRings(p.polylines(), rule)
+}
@@ -61,10 +61,8 @@ var (
 	LSBFIRST uint8 = 0
 	MSBFIRST uint8 = 1
 
-	textcolor bool
 	cursor_x  uint8
 	cursor_y  uint8
-	textsize  uint8
 )
 
 type MonthType int
@@ -115,11 +113,6 @@ func Month2String(m MonthType) string {
 }
 
 func init() {
-	//open gpio
-	err := rpio.Open()
-	if err != nil {
-		log.Fatal(err)
-	}
 	for key, value := range FONTS {
 		dict.Set(byte(key)+0x20, value)
 	}
@@ -271,43 +264,14 @@ const LCDHEIGHT uint8 = 48
 // the memory buffer for the LCD
 var pcd8544_buffer [6][LCDWIDTH]byte
 
-var pi_logo []byte = []byte{
-	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, // 0x0010 (16) pixels
-	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0xF8, 0xF8, 0xFC, 0xAE, 0x0E, 0x0E, 0x06, 0x0E, 0x06, // 0x0020 (32) pixels
-	0xCE, 0x86, 0x8E, 0x0E, 0x0E, 0x1C, 0xB8, 0xF0, 0xF8, 0x78, 0x38, 0x1E, 0x0E, 0x8E, 0x8E, 0xC6, // 0x0030 (48) pixels
-	0x0E, 0x06, 0x0E, 0x06, 0x0E, 0x9E, 0xFE, 0xFC, 0xF8, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, // 0x0040 (64) pixels
-	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, // 0x0050 (80) pixels
-	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, // 0x0060 (96) pixels
-	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x03, 0x0F, 0x0F, 0xFE, // 0x0070 (112) pixels
-	0xF8, 0xF0, 0x60, 0x60, 0xE0, 0xE1, 0xE3, 0xF7, 0x7E, 0x3E, 0x1E, 0x1F, 0x1F, 0x1F, 0x3E, 0x7E, // 0x0080 (128) pixels
-	0xFB, 0xF3, 0xE1, 0xE0, 0x60, 0x70, 0xF0, 0xF8, 0xBE, 0x1F, 0x0F, 0x07, 0x00, 0x00, 0x00, 0x00, // 0x0090 (144) pixels
-	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, // 0x00A0 (160) pixels
-	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, // 0x00B0 (176) pixels
-	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x80, 0xC0, // 0x00C0 (192) pixels
-	0xE0, 0xFC, 0xFE, 0xFF, 0xF3, 0x38, 0x38, 0x0C, 0x0E, 0x0F, 0x0F, 0x0F, 0x0E, 0x3C, 0x38, 0xF8, // 0x00D0 (208) pixels
-	0xF8, 0x38, 0x3C, 0x0E, 0x0F, 0x0F, 0x0F, 0x0E, 0x0C, 0x38, 0x38, 0xF3, 0xFF, 0xFF, 0xF8, 0xE0, // 0x00E0 (224) pixels
-	0x80, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, // 0x00F0 (240) pixels
-	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, // 0x0100 (256) pixels
-	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, // 0x0110 (272) pixels
-	0x00, 0x7F, 0xFF, 0xE7, 0xC3, 0xC1, 0xE0, 0xFF, 0xFF, 0x78, 0xE0, 0xC0, 0xC0, 0xC0, 0xC0, 0xE0, // 0x0120 (288) pixels
-	0x60, 0x78, 0x38, 0x3F, 0x3F, 0x38, 0x38, 0x60, 0x60, 0xC0, 0xC0, 0xC0, 0xC0, 0xE0, 0xF8, 0x7F, // 0x0130 (304) pixels
-	0xFF, 0xE0, 0xC1, 0xC3, 0xE7, 0x7F, 0x3E, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, // 0x0140 (320) pixels
-	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, // 0x0150 (336) pixels
-	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, // 0x0160 (352) pixels
-	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x03, 0x0F, 0x7F, 0xFF, 0xF1, 0xE0, 0xC0, 0x80, 0x01, // 0x0170 (368) pixels
-	0x03, 0x9F, 0xFF, 0xF0, 0xE0, 0xE0, 0xC0, 0xC0, 0xC0, 0xC0, 0xC0, 0xE0, 0xE0, 0xF0, 0xFF, 0x9F, // 0x0180 (384) pixels
-	0x03, 0x01, 0x80, 0xC0, 0xE0, 0xF1, 0x7F, 0x1F, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, // 0x0190 (400) pixels
-	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, // 0x01A0 (416) pixels
-	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, // 0x01B0 (432) pixels
-	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01, // 0x01C0 (448) pixels
-	0x03, 0x03, 0x07, 0x07, 0x0F, 0x1F, 0x1F, 0x3F, 0x3B, 0x71, 0x60, 0x60, 0x60, 0x60, 0x60, 0x71, // 0x01D0 (464) pixels
-	0x3B, 0x1F, 0x0F, 0x0F, 0x0F, 0x07, 0x03, 0x03, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, // 0x01E0 (480) pixels
-	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, // 0x01F0 (496) pixels
-	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-}
-
 func LCDInit(SCLK, DIN, DC, CS, RST, BL, contrast uint8) (pin PCD8544_pin) {
 
+	//open gpio, here rather than in init so the drawing code can run without it
+	err := rpio.Open()
+	if err != nil {
+		log.Fatal(err)
+	}
+
 	_contrast := contrast
 
 	dinPin := rpio.Pin(DIN)
@@ -328,8 +292,8 @@ func LCDInit(SCLK, DIN, DC, CS, RST, BL, contrast uint8) (pin PCD8544_pin) {
 
 	cursor_x = 0
 	cursor_y = 0
-	textsize = 1
-	textcolor = BLACK
+	LCDSetTextSize(1)
+	LCDSetTextColor(TextNormal)
 
 	//set output mode
 	dinPin.Output()
@@ -443,21 +407,11 @@ func (pin PCD8544_pin) LCDDisplay() {
 	pin.LCDCommand(PCD8544_SETYADDR) // no idea why this is necessary but it is to finish the last byte?
 }
 
+// LCDShowRpiLogo shows the "logo/raspberry" splash screen
 func (pin PCD8544_pin) LCDShowRpiLogo() {
-	var i int
-	for i = 0; i < 6; i++ {
-		/*
-			pi_logo_slice -> pi_logo[0:84]
-			                 pi_logo[84:168]
-							 pi_logo[168:252]
-							 pi_logo[252:336]
-							 pi_logo[336:420]
-							 pi_logo[420:504]
-		*/
-		pi_logo_slice := pi_logo[(i * (len(pi_logo) / 6)):((i + 1) * 84)]
-		copy(pcd8544_buffer[i][:], pi_logo_slice[:])
+	if err := pin.ShowSplash("logo/raspberry"); err != nil {
+		log.Println(err)
 	}
-	pin.LCDDisplay()
 }
 
 func LCDClear() {
@@ -465,7 +419,7 @@ func LCDClear() {
 	var j uint8
 
 	for i = 0; i < 6; i++ {
-		for j = 0; j < LCDWIDTH-1; j++ {
+		for j = 0; j < LCDWIDTH; j++ {
 			pcd8544_buffer[i][j] = 0
 		}
 	}
@@ -492,12 +446,17 @@ func _delay_ms(t uint32) {
 	}
 }
 
-/*
- y = uint8{0,1,2,3,4,5}
-*/
-func LCDDrawString(x uint8, y uint8, val []byte) {
+// LCDSetCursor moves the text cursor of LCDWrite to pixel (x, y), the top
+// left corner of the next character
+func LCDSetCursor(x uint8, y uint8) {
 	cursor_x = x
 	cursor_y = y
+}
+
+// LCDDrawString writes val with LCDWrite starting at pixel (x, y), y does
+// not need to be a multiple of 8: text lines are at y = 0, 8, ... 40
+func LCDDrawString(x uint8, y uint8, val []byte) {
+	LCDSetCursor(x, y)
 	//setup for debug
 	//	fmt.Printf("LCDDrawString -> val = %s\n",string(val))
 	for i := 0; i < len(val); i++ {
@@ -505,134 +464,93 @@ func LCDDrawString(x uint8, y uint8, val []byte) {
 	}
 }
 
+// LCDWrite draws c at the cursor and moves the cursor on. Lines wrap at the
+// right edge, and back to the top when the next line would not fit.
 func LCDWrite(c byte) {
+	sx, sy := Screen.TextScale()
 
 	if c == '\n' {
-		cursor_y += textsize * 8
 		cursor_x = 0
+		cursor_y += uint8(sy * 8)
 	} else if c == '\r' {
 		//skip em
 	} else {
 		LCDDrawchar(cursor_x, cursor_y, c)
-		cursor_x += textsize * 6
-		if cursor_x >= (LCDWIDTH - 5) {
+		cursor_x += uint8(sx * 6)
+		if int(cursor_x)+sx*5 > int(LCDWIDTH) {
 			cursor_x = 0
-			cursor_y += 8
-		}
-		if cursor_y >= LCDHEIGHT {
-			cursor_y = 0
-
+			cursor_y += uint8(sy * 8)
 		}
 	}
+	if int(cursor_y)+sy*8 > int(LCDHEIGHT) {
+		cursor_y = 0
+	}
 }
 
+// LCDDrawchar draws c with its top left corner at pixel (x, y), glyphs
+// that are not page aligned are split over two pages of pcd8544_buffer
 func LCDDrawchar(x uint8, y uint8, c byte) int {
 	if y >= LCDHEIGHT {
 		return 0
 	}
-	if x+5 >= LCDWIDTH {
+	if x >= LCDWIDTH {
 		return 0
 	}
 	if c < 0x20 || c > 0xb3 {
 		// out of range
 		return 0
 	}
-	var i uint8
-	for i = 0; i < 5; i++ {
-		charIndex := c
-		//pcd8544_buffer[y][x+i] = FONTS[charIndex][i]
-		pcd8544_buffer[y][x+i] = dict.Get(charIndex)[i]
-
-	}
-	return int(x + 6)
+	return Screen.DrawChar(int(x), int(y), c)
 
 }
 
-func LCDDrawPixel(x uint8, y uint8) {
-	pcd8544_buffer[y>>3][x] |= 1 << (y % 8)
-
+// LCDSetTextSize draws the following text size times as wide and high,
+// 1 is the normal 5x7 font
+func LCDSetTextSize(size uint8) {
+	Screen.SetTextScale(int(size), int(size))
 }
 
-func Swap(x *uint8, y *uint8) {
-
-	temp := *x
-	*x = *y
-	*y = temp
+// LCDSetTextScale scales text separately in width and height, e.g. (1, 2)
+// for a double height line
+func LCDSetTextScale(sx uint8, sy uint8) {
+	Screen.SetTextScale(int(sx), int(sy))
 }
 
-func Abs(n uint8) uint8 {
-	if n < 0 {
-		return (-n)
-	}
-	return n
+// LCDSetTextColor sets the text colours of Screen, e.g. TextInverse for a header
+func LCDSetTextColor(tc TextColor) {
+	Screen.SetTextColor(tc)
 }
 
-func LCDDrawLine(x0 uint8, y0 uint8, x1 uint8, y1 uint8) {
-	var (
-		steep               bool
-		deltax, deltay, err uint8
-		x, y                uint8
-		ystep               int8
-	)
-
-	// simple clipping is done in the drawPixel routine
-	/*
-	   if (( x0 < 0) || (x0 > HRES)) return;
-	   if (( x1 < 0) || (x1 > HRES)) return;
-	   if (( y0 < 0) || (y0 > VRES)) return;
-	   if (( y1 < 0) || (y1 > VRES)) return;
-	*/
-
-	steep = Abs(y1-y0) > Abs(x1-x0)
-
-	if steep {
-		Swap(&x0, &y0)
-		Swap(&x1, &y1)
-	}
-
-	if x0 > x1 {
-		Swap(&x0, &x1)
-		Swap(&y0, &y1)
-	}
-
-	deltax = x1 - x0
-	deltay = Abs(y1 - y0)
-
-	err = 0
-	y = y0
+// LCDDrawPixel, LCDDrawLine and the other LCDDraw* functions draw on Screen,
+// see canvas.go for the int based versions with clipping
 
-	if y0 < y1 {
-		ystep = 1
-	} else {
-		ystep = -1
-	}
+// LCDSetDrawMode sets the draw mode of Screen (DrawSet, DrawClear or DrawInvert),
+// text uses its colours in DrawSet mode only, see SetTextColor
+func LCDSetDrawMode(mode DrawMode) {
+	Screen.SetMode(mode)
+}
 
-	for x = x0; x < x1; x++ {
-		if steep {
-			LCDDrawPixel(y, x)
-		} else {
-			LCDDrawPixel(x, y)
-		}
-		err += deltay
+// LCDSetTransparent makes bitmaps on Screen leave their background alone
+func LCDSetTransparent(transparent bool) {
+	Screen.SetTransparent(transparent)
+}
 
-		if (err << 1) >= deltax {
+func LCDDrawPixel(x uint8, y uint8) {
+	Screen.DrawPixel(int(x), int(y))
+}
 
-			y += (uint8)(ystep)
-			err -= deltax
-		}
-	}
+func LCDDrawLine(x0 uint8, y0 uint8, x1 uint8, y1 uint8) {
+	Screen.DrawLine(int(x0), int(y0), int(x1), int(y1))
 }
 
 func LCDDrawVLine(x uint8, y uint8, h uint8) {
-	LCDDrawLine(x, y, x, y+h-1)
+	Screen.DrawVLine(int(x), int(y), int(h))
 }
 
 func LCDDrawHLine(x uint8, y uint8, w uint8) {
-	LCDDrawLine(x, y, x+w-1, y)
+	Screen.DrawHLine(int(x), int(y), int(w))
 }
 
 func LCDDrawTriangle(x1 uint8, y1 uint8, x2 uint8, y2 uint8, x3 uint8, y3 uint8) {
-	LCDDrawLine(x1, y1, x2, y2)
-	LCDDrawLine(x2, y2, x3, y3)
-	LCDDrawLine(x3, y3, x1, y1)
+	Screen.DrawTriangle(int(x1), int(y1), int(x2), int(y2), int(x3), int(y3))
 }
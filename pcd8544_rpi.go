@@ -192,12 +192,19 @@ func main() {
 		//-----------------
 	*/
 
+	//bitmaps in this directory override the built-in assets
+	if dir := os.Getenv("PCD8544_ASSETS"); dir != "" {
+		SetAssetDir(dir)
+	}
+
 	//Init LCD
 	pin := LCDInit(SCLK, DIN, DC, CS, RST, BL, contrast)
 
 	LCDClear()
 
-	pin.LCDShowRpiLogo()
+	if err := pin.ShowSplash("logo/raspberry"); err != nil {
+		fmt.Printf("Show splash error ->[%s]\n", err.Error())
+	}
 	time.Sleep(4 * time.Second) // 4000ms -> 4s
 
 	for {
@@ -249,12 +256,12 @@ func main() {
 
 		LCDDrawString(0, 0, ipInfo) //line0
 		LCDDrawLine(0, 8, 83, 8)
-		LCDDrawString(0, 1, []byte(uptimeInfo)) //line1
-		LCDDrawString(0, 2, timeInfoBytes)      //line2
-		LCDDrawString(0, 3, []byte(cpuinfo))    //line3
-		LCDDrawString(0, 4, []byte(ramInfo))    //line4
+		LCDDrawString(0, 8, []byte(uptimeInfo)) //line1
+		LCDDrawString(0, 16, timeInfoBytes)     //line2
+		LCDDrawString(0, 24, []byte(cpuinfo))   //line3
+		LCDDrawString(0, 32, []byte(ramInfo))   //line4
 
-		LCDDrawString(0, 5, []byte(cpuTempInfo)) //line5
+		LCDDrawString(0, 40, []byte(cpuTempInfo)) //line5
 
 		pin.LCDDisplay()
 
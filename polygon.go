@@ -0,0 +1,119 @@
+package main
+
+import (
+	"image"
+	"math"
+	"sort"
+)
+
+// FillRule decides which parts of a self-intersecting or nested polygon are inside
+type FillRule int
+
+const (
+	FillEvenOdd FillRule = iota // inside where a ray crosses the outline an odd number of times
+	FillNonZero                 // inside where the outline winds around the point at all
+)
+
+// DrawPolygon draws the closed outline through pts using the line style
+func (c *Canvas) DrawPolygon(pts []image.Point) {
+	if len(pts) == 0 {
+		return
+	}
+	ring := append(append([]image.Point{}, pts...), pts[0])
+	c.strokePolylines([][]image.Point{ring})
+}
+
+// FillTriangle fills a triangle, the outline DrawTriangle draws is included
+func (c *Canvas) FillTriangle(x0, y0, x1, y1, x2, y2 int) {
+	c.FillPolygon([]image.Point{{x0, y0}, {x1, y1}, {x2, y2}}, FillNonZero)
+}
+
+// FillPolygon fills the closed polygon through pts, which may be concave or
+// self-intersecting, using rule. Vertices are pixel centers and the outline
+// DrawPolygon draws is part of the filled area.
+func (c *Canvas) FillPolygon(pts []image.Point, rule FillRule) {
+	c.fillRings([][]image.Point{pts}, rule)
+}
+
+// fillRings fills several closed polygons as one shape, so that with the
+// right rule an inner ring cuts a hole into an outer one
+func (c *Canvas) fillRings(rings [][]image.Point, rule FillRule) {
+	// rasterize into a mask first, so every pixel is drawn exactly once
+	// even where the outline and the inside overlap
+	var mask [6][LCDWIDTH]byte
+	m := c.maskCanvas(&mask)
+
+	var bounds image.Rectangle
+	for _, pts := range rings {
+		m.DrawPolygon(pts)
+		for _, p := range pts {
+			bounds = bounds.Union(image.Rectangle{p, p.Add(image.Point{1, 1})})
+		}
+	}
+	bounds = bounds.Intersect(c.Clip())
+
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for _, s := range scanPolygon(rings, y, rule) {
+			x0 := int(math.Ceil(s[0]))
+			x1 := int(math.Floor(s[1]))
+			if x1 >= x0 {
+				m.DrawHLine(x0, y, x1-x0+1)
+			}
+		}
+	}
+	c.fillMask(&mask)
+}
+
+// crossing is where an edge of a polygon crosses a scanline
+type crossing struct {
+	x   float64
+	dir int
+}
+
+// scanPolygon returns the inside spans [x0, x1] of the polygons on row y
+func scanPolygon(rings [][]image.Point, y int, rule FillRule) [][2]float64 {
+	var xs []crossing
+	for _, pts := range rings {
+		for i := range pts {
+			a, b := pts[i], pts[(i+1)%len(pts)]
+			// half open, so a vertex shared by two edges counts only once
+			dir := 1
+			if a.Y > b.Y {
+				a, b = b, a
+				dir = -1
+			}
+			if y < a.Y || y >= b.Y {
+				continue
+			}
+			x := float64(a.X) + float64(y-a.Y)*float64(b.X-a.X)/float64(b.Y-a.Y)
+			xs = append(xs, crossing{x, dir})
+		}
+	}
+	sort.Slice(xs, func(i, j int) bool { return xs[i].x < xs[j].x })
+
+	var spans [][2]float64
+	winding := 0
+	for i := 0; i+1 < len(xs); i++ {
+		if rule == FillEvenOdd {
+			winding ^= 1
+		} else {
+			winding += xs[i].dir
+		}
+		if winding != 0 {
+			spans = append(spans, [2]float64{xs[i].x, xs[i+1].x})
+		}
+	}
+	return spans
+}
+
+// blend draws the pixels set in mask onto the canvas, a page byte at a
+// time. mask is in buffer coordinates, see maskCanvas.
+func (c *Canvas) blend(mask *[6][LCDWIDTH]byte) {
+	for p := range mask {
+		for x := range mask[p] {
+			if bits := mask[p][x]; bits != 0 {
+				c.put(x, p, bits, bits)
+			}
+		}
+	}
+}
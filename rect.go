@@ -0,0 +1,184 @@
+package main
+
+import (
+	"image"
+)
+
+// DrawRect draws the outline of the w*h rectangle at (x, y)
+func (c *Canvas) DrawRect(x, y, w, h int) {
+	if w <= 0 || h <= 0 {
+		return
+	}
+	if !c.style.plain() {
+		c.strokePolylines([][]image.Point{{
+			{x, y}, {x + w - 1, y}, {x + w - 1, y + h - 1}, {x, y + h - 1}, {x, y},
+		}})
+		return
+	}
+	// corners are drawn only once, so DrawInvert works too
+	c.DrawHLine(x, y, w)
+	if h > 1 {
+		c.DrawHLine(x, y+h-1, w)
+	}
+	if h > 2 {
+		c.DrawVLine(x, y+1, h-2)
+		if w > 1 {
+			c.DrawVLine(x+w-1, y+1, h-2)
+		}
+	}
+}
+
+// FillRect fills the w*h rectangle at (x, y), whole page bytes at a time
+func (c *Canvas) FillRect(x, y, w, h int) {
+	if w <= 0 || h <= 0 {
+		return
+	}
+	r := image.Rect(x, y, x+w, y+h).Intersect(c.Clip())
+	for x := r.Min.X; x < r.Max.X; x++ {
+		c.fillColumn(x, r.Min.Y, r.Max.Y-1)
+	}
+}
+
+// DrawRoundRect draws the outline of a rectangle with corners of radius r
+func (c *Canvas) DrawRoundRect(x, y, w, h, r int) {
+	if w <= 0 || h <= 0 {
+		return
+	}
+	r = clampRadius(w, h, r)
+	if r == 0 {
+		c.DrawRect(x, y, w, h)
+		return
+	}
+	if !c.style.plain() {
+		c.StrokePath(roundRectPath(x, y, w, h, r))
+		return
+	}
+	c.strokeRounded(x+r, y+r, x+w-1-r, y+h-1-r, circleExtents(r))
+}
+
+// FillRoundRect fills a rectangle with corners of radius r
+func (c *Canvas) FillRoundRect(x, y, w, h, r int) {
+	if w <= 0 || h <= 0 {
+		return
+	}
+	r = clampRadius(w, h, r)
+	c.fillRounded(x+r, y+r, x+w-1-r, y+h-1-r, circleExtents(r))
+}
+
+// roundRectPath returns the outline of a rounded rectangle as a path, for
+// outlines with a line style
+func roundRectPath(x, y, w, h, r int) *Path {
+	x0, y0 := float64(x), float64(y)
+	x1, y1 := float64(x+w-1), float64(y+h-1)
+	rr := float64(r)
+
+	p := NewPath()
+	p.MoveTo(x0+rr, y0)
+	p.ArcTo(x1, y0, x1, y1, rr)
+	p.ArcTo(x1, y1, x0, y1, rr)
+	p.ArcTo(x0, y1, x0, y0, rr)
+	p.ArcTo(x0, y0, x1, y0, rr)
+	p.Close()
+	return p
+}
+
+func clampRadius(w, h, r int) int {
+	if r > (w-1)/2 {
+		r = (w - 1) / 2
+	}
+	if r > (h-1)/2 {
+		r = (h - 1) / 2
+	}
+	if r < 0 {
+		r = 0
+	}
+	return r
+}
+
+// circleExtents runs the midpoint circle algorithm and returns, for every row
+// 0 <= dy <= r away from the center, how far the circle reaches horizontally
+func circleExtents(r int) []int {
+	ext := make([]int, r+1)
+	x, y := 0, r
+	f := 1 - r
+	for x <= y {
+		// the octant point (x, y) and its mirror (y, x)
+		if x > ext[y] {
+			ext[y] = x
+		}
+		if y > ext[x] {
+			ext[x] = y
+		}
+		if f < 0 {
+			f += 2*x + 3
+		} else {
+			f += 2*(x-y) + 5
+			y--
+		}
+		x++
+	}
+	return ext
+}
+
+// strokeRounded draws the outline of a round shape given by its row extents
+// (see circleExtents). The shape is split at the centers (cx0, cy0) and
+// (cx1, cy1), the gaps in between become straight edges, which turns a
+// circle into a rounded rectangle. Every pixel is drawn once.
+func (c *Canvas) strokeRounded(cx0, cy0, cx1, cy1 int, ext []int) {
+	n := len(ext) - 1
+	for dy := -n; dy <= n; dy++ {
+		a, b := outlineRun(ext, abs(dy))
+
+		y0, y1 := cy0+dy, cy0+dy
+		if dy > 0 {
+			y0, y1 = cy1+dy, cy1+dy
+		} else if dy == 0 {
+			y1 = cy1
+		}
+		for y := y0; y <= y1; y++ {
+			if a == 0 {
+				c.drawLine(cx0-b, y, cx1+b, y)
+				continue
+			}
+			c.drawLine(cx0-b, y, cx0-a, y)
+			c.drawLine(cx1+a, y, cx1+b, y)
+		}
+	}
+}
+
+// outlineRun returns the horizontal run a <= dx <= b of the outline in row
+// ad of a round shape, it starts where the run of the next row ended
+func outlineRun(ext []int, ad int) (a, b int) {
+	n := len(ext) - 1
+	b = ext[ad]
+	if ad == n {
+		return 0, b
+	}
+	a = b
+	if ext[ad+1]+1 < a {
+		a = ext[ad+1] + 1
+	}
+	return a, b
+}
+
+// fillRounded fills the shape strokeRounded outlines, one column at a time
+func (c *Canvas) fillRounded(cx0, cy0, cx1, cy1 int, ext []int) {
+	n := len(ext) - 1
+	for dx := -ext[0]; dx <= ext[0]; dx++ {
+		// the vertical extent of this column is the last row reaching it
+		ey := 0
+		for ey < n && ext[ey+1] >= abs(dx) {
+			ey++
+		}
+
+		x0, x1 := cx0+dx, cx0+dx
+		if dx > 0 {
+			x0, x1 = cx1+dx, cx1+dx
+		} else if dx == 0 {
+			x1 = cx1
+		}
+		for x := x0; x <= x1; x++ {
+			c.fillColumn(x, cy0-ey, cy1+ey)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"image"
+)
+
+// InvertRect flips every pixel of the w*h rectangle at (x, y), for example
+// to highlight the selected row of a menu. The draw mode is not used.
+func (c *Canvas) InvertRect(x, y, w, h int) {
+	c.with(DrawInvert, func() {
+		c.FillRect(x, y, w, h)
+	})
+}
+
+// GetRect returns a copy of the pixels in r, pixels outside the canvas are
+// clear. r is made canonical first, so its corners may be given in any order.
+func (c *Canvas) GetRect(r image.Rectangle) *Bitmap {
+	r = r.Canon()
+	b := NewBitmap(r.Dx(), r.Dy())
+	for y := 0; y < b.Height; y++ {
+		for x := 0; x < b.Width; x++ {
+			if c.Pixel(r.Min.X+x, r.Min.Y+y) {
+				b.Set(x, y, true)
+			}
+		}
+	}
+	return b
+}
+
+// PutRect puts back pixels saved with GetRect with the top left corner at
+// (x, y). Unlike Blit it copies them exactly, whatever the draw mode is.
+func (c *Canvas) PutRect(x, y int, b *Bitmap) {
+	c.with(DrawSet, func() {
+		c.Blit(x, y, b, nil)
+	})
+}
+
+// CopyRect copies the pixels in src so that its top left corner ends up at
+// dst. Source and destination may overlap.
+func (c *Canvas) CopyRect(src image.Rectangle, dst image.Point) {
+	c.PutRect(dst.X, dst.Y, c.GetRect(src))
+}
+
+// MoveRect moves the pixels in src to dst like CopyRect, the part of src
+// that is left behind is set to color (BLACK or WHITE). Like GetRect it
+// takes the corners of src in any order.
+func (c *Canvas) MoveRect(src image.Rectangle, dst image.Point, color bool) {
+	src = src.Canon()
+	b := c.GetRect(src)
+	mode := DrawClear
+	if color == BLACK {
+		mode = DrawSet
+	}
+	c.with(mode, func() {
+		c.FillRect(src.Min.X, src.Min.Y, src.Dx(), src.Dy())
+	})
+	c.PutRect(dst.X, dst.Y, b)
+}
+
+// with runs f with the draw mode set to mode, drawing backgrounds and
+// filling solid, then restores the canvas settings
+func (c *Canvas) with(mode DrawMode, f func()) {
+	saved, transparent, pattern := c.mode, c.transparent, c.pattern
+	c.mode, c.transparent, c.pattern = mode, false, PatternSolid
+	f()
+	c.mode, c.transparent, c.pattern = saved, transparent, pattern
+}
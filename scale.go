@@ -0,0 +1,131 @@
+package main
+
+import (
+	"image"
+	"math"
+)
+
+// ScaleFilter selects how Bitmap.Scale picks the pixels of the result
+type ScaleFilter int
+
+const (
+	// take the source pixel under the center of every result pixel
+	ScaleNearest ScaleFilter = iota
+	// set a result pixel when at least half of the source pixels it covers
+	// are set, which keeps thin lines and small text readable when shrinking
+	ScaleBox
+)
+
+// Scale returns a copy of b resized to w*h pixels, negative sizes are taken
+// as 0 like in NewBitmap
+func (b *Bitmap) Scale(w, h int, filter ScaleFilter) *Bitmap {
+	out := NewBitmap(w, h)
+	if b.Width <= 0 || b.Height <= 0 {
+		return out
+	}
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			var on bool
+			if filter == ScaleBox {
+				on = b.boxSample(x*b.Width/w, (x+1)*b.Width/w, y*b.Height/h, (y+1)*b.Height/h)
+			} else {
+				on = b.At((2*x+1)*b.Width/(2*w), (2*y+1)*b.Height/(2*h))
+			}
+			if on {
+				out.Set(x, y, true)
+			}
+		}
+	}
+	return out
+}
+
+// boxSample reports whether at least half of the pixels x0 <= x < x1,
+// y0 <= y < y1 are set, the area is at least one pixel
+func (b *Bitmap) boxSample(x0, x1, y0, y1 int) bool {
+	if x1 <= x0 {
+		x1 = x0 + 1
+	}
+	if y1 <= y0 {
+		y1 = y0 + 1
+	}
+	n, set := 0, 0
+	for y := y0; y < y1; y++ {
+		for x := x0; x < x1; x++ {
+			if b.At(x, y) {
+				set++
+			}
+			n++
+		}
+	}
+	return 2*set >= n
+}
+
+// Rotate turns b by angle degrees clockwise around the pixel pivot. It
+// returns the rotated bitmap, a mask of the pixels that are covered by b
+// and where the pivot is in the result.
+func (b *Bitmap) Rotate(angle float64, pivot image.Point) (rot, mask *Bitmap, at image.Point) {
+	sin, cos := math.Sincos(angle * math.Pi / 180)
+
+	// rotate the corners of b, relative to the center of the pivot pixel,
+	// to find the size of the result
+	minX, minY := math.Inf(1), math.Inf(1)
+	maxX, maxY := math.Inf(-1), math.Inf(-1)
+	for _, c := range [][2]float64{{0, 0}, {1, 0}, {0, 1}, {1, 1}} {
+		u := c[0]*float64(b.Width) - float64(pivot.X) - 0.5
+		v := c[1]*float64(b.Height) - float64(pivot.Y) - 0.5
+		x, y := u*cos-v*sin, u*sin+v*cos
+		minX, maxX = math.Min(minX, x), math.Max(maxX, x)
+		minY, maxY = math.Min(minY, y), math.Max(maxY, y)
+	}
+	// the corners lie halfway between pixel centers, which are at whole offsets
+	x0, y0 := int(math.Ceil(minX)), int(math.Ceil(minY))
+	x1, y1 := int(math.Floor(maxX)), int(math.Floor(maxY))
+
+	rot, mask = NewBitmap(x1-x0+1, y1-y0+1), NewBitmap(x1-x0+1, y1-y0+1)
+	for y := y0; y <= y1; y++ {
+		for x := x0; x <= x1; x++ {
+			// turn the result pixel back to find its source pixel
+			u, v := float64(x), float64(y)
+			sx := int(math.Floor(float64(pivot.X) + 0.5 + u*cos + v*sin))
+			sy := int(math.Floor(float64(pivot.Y) + 0.5 - u*sin + v*cos))
+			if sx < 0 || sy < 0 || sx >= b.Width || sy >= b.Height {
+				continue
+			}
+			mask.Set(x-x0, y-y0, true)
+			rot.Set(x-x0, y-y0, b.At(sx, sy))
+		}
+	}
+	return rot, mask, image.Pt(-x0, -y0)
+}
+
+// DrawRotated draws src turned by angle degrees clockwise around its pixel
+// pivot, which ends up at (x, y). Like Blit, only the pixels set in mask
+// are drawn if it is not nil, where mask is smaller than src the pixels are
+// not drawn. Pixels outside the turned src are untouched.
+func (c *Canvas) DrawRotated(x, y int, src, mask *Bitmap, angle float64, pivot image.Point) {
+	if src == nil {
+		return
+	}
+	rot, in, at := src.Rotate(angle, pivot)
+	if mask != nil {
+		if mask.Width != src.Width || mask.Height != src.Height {
+			// the mask has to turn with the same bounding box as src
+			sized := NewBitmap(src.Width, src.Height)
+			for my := 0; my < src.Height; my++ {
+				for mx := 0; mx < src.Width; mx++ {
+					sized.Set(mx, my, mask.At(mx, my))
+				}
+			}
+			mask = sized
+		}
+		m, _, _ := mask.Rotate(angle, pivot)
+		for my := 0; my < in.Height; my++ {
+			for mx := 0; mx < in.Width; mx++ {
+				if !m.At(mx, my) {
+					in.Set(mx, my, false)
+				}
+			}
+		}
+	}
+	c.Blit(x-at.X, y-at.Y, rot, in)
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"image"
+	"testing"
+)
+
+func TestNegativeSize(t *testing.T) {
+	for _, wh := range [][2]int{{-2, 5}, {5, -2}, {-1, -1}, {0, 0}} {
+		b := NewBitmap(wh[0], wh[1])
+		if b.Width < 0 || b.Height < 0 || len(b.Data) != 0 {
+			t.Errorf("NewBitmap(%d, %d) is %dx%d with %d bytes", wh[0], wh[1], b.Width, b.Height, len(b.Data))
+		}
+		s := NewBitmap(4, 4).Scale(wh[0], wh[1], ScaleBox)
+		if s.Width < 0 || s.Height < 0 || len(s.Data) != 0 {
+			t.Errorf("Scale(%d, %d) is %dx%d", wh[0], wh[1], s.Width, s.Height)
+		}
+	}
+}
+
+func TestDrawRotatedMask(t *testing.T) {
+	src := NewBitmap(8, 4)
+	for y := 0; y < 4; y++ {
+		for x := 0; x < 8; x++ {
+			src.Set(x, y, true)
+		}
+	}
+	tests := []struct {
+		name string
+		mask *Bitmap
+		want int // pixels drawn
+	}{
+		{"no mask", nil, 32},
+		{"smaller", NewBitmap(2, 2), 4},
+		{"larger", NewBitmap(20, 20), 4},
+	}
+	for _, tt := range tests {
+		if tt.mask != nil {
+			for y := 0; y < tt.mask.Height; y++ {
+				for x := 0; x < tt.mask.Width; x++ {
+					tt.mask.Set(x, y, x < 2 && y < 2)
+				}
+			}
+		}
+		var buf [6][LCDWIDTH]byte
+		NewCanvas(&buf).DrawRotated(40, 20, src, tt.mask, 90, image.Pt(0, 0))
+		if got := len(pixels(&buf)); got != tt.want {
+			t.Errorf("%s: %d pixels drawn, want %d", tt.name, got, tt.want)
+		}
+	}
+}
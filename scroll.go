@@ -0,0 +1,86 @@
+package main
+
+import (
+	"image"
+)
+
+// Scroll shifts the area inside the clip rectangle by dx pixels to the
+// right and dy pixels down, negative values shift left and up. The pixels
+// that are vacated are set to color (BLACK or WHITE).
+func (c *Canvas) Scroll(dx, dy int, color bool) {
+	c.ScrollRect(c.Clip(), dx, dy, color)
+}
+
+// ScrollRect shifts the part of r inside the clip rectangle like Scroll.
+// Pixels shifted out of r are lost, the rest of the canvas is left alone.
+// The buffer is moved a whole column at a time, so the cost does not depend
+// on what is drawn and shifts across page boundaries need no redraw.
+func (c *Canvas) ScrollRect(r image.Rectangle, dx, dy int, color bool) {
+	r = r.Add(c.origin).Intersect(c.clip)
+	if r.Empty() || (dx == 0 && dy == 0) {
+		return
+	}
+	shiftRect(c.buf, r, dx, dy, color)
+	if c.cover != nil {
+		// the layer mask moves along, the vacated pixels are drawn
+		shiftRect(c.cover, r, dx, dy, true)
+	}
+}
+
+// shiftRect does ScrollRect on buf, r is in buffer coordinates and inside
+// the screen
+func shiftRect(buf *[6][LCDWIDTH]byte, r image.Rectangle, dx, dy int, color bool) {
+	rows := columnBits(r.Min.Y, r.Max.Y)
+	// the rows of a shifted column that still come from inside r
+	kept := rows & shiftColumn(rows, dy)
+	var fill uint64
+	if color == BLACK {
+		fill = rows
+	}
+
+	// read all columns first, so it does not matter how source and
+	// destination overlap
+	var cols [LCDWIDTH]uint64
+	for x := r.Min.X; x < r.Max.X; x++ {
+		cols[x] = readColumn(buf, x)
+	}
+	for x := r.Min.X; x < r.Max.X; x++ {
+		v := cols[x]&^rows | fill
+		if sx := x - dx; sx >= r.Min.X && sx < r.Max.X {
+			v = v&^kept | shiftColumn(cols[sx], dy)&kept
+		}
+		writeColumn(buf, x, v)
+	}
+}
+
+// columnBits returns the bits of a column for the rows y0 <= y < y1
+func columnBits(y0, y1 int) uint64 {
+	return (1<<uint(y1) - 1) &^ (1<<uint(y0) - 1)
+}
+
+// shiftColumn moves the pixels of a column dy rows down
+func shiftColumn(v uint64, dy int) uint64 {
+	switch {
+	case dy >= 64 || dy <= -64:
+		return 0
+	case dy >= 0:
+		return v << uint(dy)
+	default:
+		return v >> uint(-dy)
+	}
+}
+
+// readColumn returns column x of buf with row y in bit y
+func readColumn(buf *[6][LCDWIDTH]byte, x int) uint64 {
+	var v uint64
+	for p := range buf {
+		v |= uint64(buf[p][x]) << uint(p*8)
+	}
+	return v
+}
+
+func writeColumn(buf *[6][LCDWIDTH]byte, x int, v uint64) {
+	for p := range buf {
+		buf[p][x] = byte(v >> uint(p*8))
+	}
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"image"
+	"time"
+)
+
+// Transform flips and rotates a sprite frame when it is drawn. The flips are
+// applied first, then the quarter turn.
+type Transform uint8
+
+const (
+	FlipH    Transform = 1 << iota // mirror left and right
+	FlipV                          // mirror top and bottom
+	Rotate90                       // turn a quarter clockwise
+
+	Rotate180 = FlipH | FlipV
+	Rotate270 = Rotate90 | FlipH | FlipV
+)
+
+// Sprite is an animated bitmap cut from a sprite sheet
+type Sprite struct {
+	Frames []*Bitmap
+	// Masks[i] selects the opaque pixels of Frames[i], a nil mask is fully opaque
+	Masks []*Bitmap
+	// how long every frame is shown, see FrameAt
+	FrameDuration time.Duration
+	// the point of a frame that ends up at the position passed to DrawSprite
+	Hotspot image.Point
+}
+
+// LoadSprite loads a sprite sheet image, see NewSprite
+func LoadSprite(path string, frameW, frameH int, d time.Duration) (*Sprite, error) {
+	img, err := LoadImage(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewSprite(img, frameW, frameH, d), nil
+}
+
+// NewSprite cuts sheet into frames of frameW*frameH pixels, left to right
+// and then top to bottom. Transparent pixels of the sheet become the masks.
+func NewSprite(sheet image.Image, frameW, frameH int, d time.Duration) *Sprite {
+	s := &Sprite{FrameDuration: d}
+	if frameW <= 0 || frameH <= 0 {
+		return s
+	}
+
+	r := sheet.Bounds()
+	for y := r.Min.Y; y+frameH <= r.Max.Y; y += frameH {
+		for x := r.Min.X; x+frameW <= r.Max.X; x += frameW {
+			frame, mask := BitmapFromImage(subImage(sheet, image.Rect(x, y, x+frameW, y+frameH)))
+			s.Frames = append(s.Frames, frame)
+			s.Masks = append(s.Masks, mask)
+		}
+	}
+	return s
+}
+
+// subImage returns the part r of img
+func subImage(img image.Image, r image.Rectangle) image.Image {
+	if si, ok := img.(interface {
+		SubImage(image.Rectangle) image.Image
+	}); ok {
+		return si.SubImage(r)
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, r.Dx(), r.Dy()))
+	for y := 0; y < r.Dy(); y++ {
+		for x := 0; x < r.Dx(); x++ {
+			dst.Set(x, y, img.At(r.Min.X+x, r.Min.Y+y))
+		}
+	}
+	return dst
+}
+
+// FrameAt returns the frame to show after the animation has run for elapsed
+func (s *Sprite) FrameAt(elapsed time.Duration) int {
+	if len(s.Frames) == 0 || s.FrameDuration <= 0 {
+		return 0
+	}
+	return int(elapsed/s.FrameDuration) % len(s.Frames)
+}
+
+// DrawSprite draws frame of s transformed by t, with the hotspot at (x, y)
+func (c *Canvas) DrawSprite(s *Sprite, x, y, frame int, t Transform) {
+	if len(s.Frames) == 0 {
+		return
+	}
+	frame %= len(s.Frames)
+	if frame < 0 {
+		frame += len(s.Frames)
+	}
+
+	src := s.Frames[frame]
+	var mask *Bitmap
+	if frame < len(s.Masks) {
+		mask = s.Masks[frame]
+	}
+	hot := transformPoint(s.Hotspot, src.Width, src.Height, t)
+
+	if t != 0 {
+		src = transformBitmap(src, t)
+		if mask != nil {
+			mask = transformBitmap(mask, t)
+		}
+	}
+	c.Blit(x-hot.X, y-hot.Y, src, mask)
+}
+
+// transformPoint maps a point of a w*h bitmap to where t moves it
+func transformPoint(p image.Point, w, h int, t Transform) image.Point {
+	if t&FlipH != 0 {
+		p.X = w - 1 - p.X
+	}
+	if t&FlipV != 0 {
+		p.Y = h - 1 - p.Y
+	}
+	if t&Rotate90 != 0 {
+		p.X, p.Y = h-1-p.Y, p.X
+	}
+	return p
+}
+
+// transformBitmap returns a flipped and rotated copy of b
+func transformBitmap(b *Bitmap, t Transform) *Bitmap {
+	w, h := b.Width, b.Height
+	if t&Rotate90 != 0 {
+		w, h = h, w
+	}
+	out := NewBitmap(w, h)
+	for y := 0; y < b.Height; y++ {
+		for x := 0; x < b.Width; x++ {
+			if b.At(x, y) {
+				p := transformPoint(image.Pt(x, y), b.Width, b.Height, t)
+				out.Set(p.X, p.Y, true)
+			}
+		}
+	}
+	return out
+}
@@ -0,0 +1,141 @@
+package main
+
+// TextColor is the colour of text and of the cell behind every character
+type TextColor struct {
+	Foreground bool // BLACK or WHITE
+	Background bool
+	// leave the background untouched, Background is not used
+	Transparent bool
+}
+
+var (
+	TextNormal      = TextColor{Foreground: BLACK, Background: WHITE}
+	TextInverse     = TextColor{Foreground: WHITE, Background: BLACK}
+	TextTransparent = TextColor{Foreground: BLACK, Transparent: true}
+)
+
+// colorMode returns the draw mode that draws pixels in color
+func colorMode(color bool) DrawMode {
+	if color == BLACK {
+		return DrawSet
+	}
+	return DrawClear
+}
+
+// TextScale returns how many times wider and higher than the font text is drawn
+func (c *Canvas) TextScale() (sx, sy int) {
+	return c.textScale.X, c.textScale.Y
+}
+
+// SetTextScale draws the following text sx times as wide and sy times as
+// high, scales below 1 are taken as 1
+func (c *Canvas) SetTextScale(sx, sy int) {
+	if sx < 1 {
+		sx = 1
+	}
+	if sy < 1 {
+		sy = 1
+	}
+	c.textScale.X, c.textScale.Y = sx, sy
+}
+
+// TextColor returns the current text colours
+func (c *Canvas) TextColor() TextColor {
+	return c.textColor
+}
+
+// SetTextColor sets the colours text is drawn with in DrawSet mode. In
+// DrawClear and DrawInvert mode the pixels of the characters are cleared or
+// flipped like those of any other shape and the cells are left alone,
+// whatever the colours. Text does not use SetTransparent.
+func (c *Canvas) SetTextColor(tc TextColor) {
+	c.textColor = tc
+}
+
+// DrawChar draws ch in Font5x7 with its top left corner at (x, y) and
+// returns the x of the next character
+func (c *Canvas) DrawChar(x, y int, ch byte) int {
+	return c.DrawRune(Font5x7, x, y, rune(ch))
+}
+
+// DrawString draws s in Font5x7 on one line starting at (x, y) and returns
+// the x after the last character
+func (c *Canvas) DrawString(x, y int, s string) int {
+	for i := 0; i < len(s); i++ {
+		x = c.DrawChar(x, y, s[i])
+	}
+	return x
+}
+
+// DrawRune draws r in font f with the top left corner of its line at
+// (x, y) and returns the x of the next character. Runes the font does not
+// have are drawn as a space. Unless the text colour is transparent the
+// whole cell up to the next character and line is filled with the
+// background, so inverse text makes a solid bar. The colours only apply in
+// DrawSet mode, see SetTextColor.
+func (c *Canvas) DrawRune(f Font, x, y int, r rune) int {
+	g, ok := f.Glyph(r)
+	if !ok {
+		g, _ = f.Glyph(' ')
+	}
+	m := f.Metrics()
+	sx, sy := c.TextScale()
+
+	mode, transparent, pattern := c.mode, c.transparent, c.pattern
+	c.transparent, c.pattern = true, PatternSolid
+	colors := mode == DrawSet
+	if tc := c.textColor; colors && !tc.Transparent {
+		c.mode = colorMode(tc.Background)
+		c.FillRect(x, y, g.Advance*sx, m.Height*sy)
+	}
+	if bm := g.Bitmap; bm != nil {
+		if sx != 1 || sy != 1 {
+			bm = bm.Scale(bm.Width*sx, bm.Height*sy, ScaleNearest)
+		}
+		if colors {
+			c.mode = colorMode(c.textColor.Foreground)
+		}
+		c.Blit(x+g.Offset.X*sx, y+(m.Ascent+g.Offset.Y)*sy, bm, nil)
+	}
+	c.mode, c.transparent, c.pattern = mode, transparent, pattern
+	return x + g.Advance*sx
+}
+
+// DrawText draws s in font f with the top left corner of the first line at
+// (x, y), a newline starts the next line at x again. It returns the x after
+// the last character.
+func (c *Canvas) DrawText(f Font, x, y int, s string) int {
+	_, sy := c.TextScale()
+	pen := x
+	for _, r := range s {
+		if r == '\n' {
+			pen = x
+			y += f.Metrics().Height * sy
+			continue
+		}
+		pen = c.DrawRune(f, pen, y, r)
+	}
+	return pen
+}
+
+// TextWidth returns how wide the longest line of s is in font f, with the
+// text scale of the canvas
+func (c *Canvas) TextWidth(f Font, s string) int {
+	sx, _ := c.TextScale()
+	w, max := 0, 0
+	for _, r := range s {
+		if r == '\n' {
+			w = 0
+			continue
+		}
+		g, ok := f.Glyph(r)
+		if !ok {
+			g, _ = f.Glyph(' ')
+		}
+		w += g.Advance * sx
+		if w > max {
+			max = w
+		}
+	}
+	return max
+}
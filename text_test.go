@@ -0,0 +1,49 @@
+package main
+
+import (
+	"image"
+	"testing"
+)
+
+func TestTextColor(t *testing.T) {
+	tests := []struct {
+		name  string
+		mode  DrawMode
+		color TextColor
+		fill  bool // start from a black screen
+		// pixels of the 6x8 cell of "X" at (0, 0) that are set afterwards
+		want int
+	}{
+		{"normal", DrawSet, TextNormal, true, 13},
+		{"inverse", DrawSet, TextInverse, false, 48 - 13},
+		{"transparent", DrawSet, TextTransparent, true, 48},
+		{"clear", DrawClear, TextNormal, false, 0},
+		{"clear on black", DrawClear, TextNormal, true, 48 - 13},
+		{"invert", DrawInvert, TextInverse, true, 48 - 13},
+	}
+	for _, tt := range tests {
+		var buf [6][LCDWIDTH]byte
+		c := NewCanvas(&buf)
+		if tt.fill {
+			c.FillRect(0, 0, 84, 48)
+		}
+		c.SetMode(tt.mode)
+		c.SetTextColor(tt.color)
+		if x := c.DrawText(Font5x7, 0, 0, "X"); x != 6 {
+			t.Fatalf("%s: next x %d", tt.name, x)
+		}
+
+		got := 0
+		for p := range pixels(&buf) {
+			if p.In(image.Rect(0, 0, 6, 8)) {
+				got++
+			}
+		}
+		if got != tt.want {
+			t.Errorf("%s: %d pixels set, want %d", tt.name, got, tt.want)
+		}
+		if c.Mode() != tt.mode {
+			t.Errorf("%s: mode not restored", tt.name)
+		}
+	}
+}